@@ -0,0 +1,69 @@
+package gocast
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+)
+
+// ArrayLengthPolicy defines the conversion into the fixed-size array
+// if the length of the source differs from the array length
+type ArrayLengthPolicy int
+
+const (
+	// ArrayLengthZeroPad fills the rest of the array by zero values if the source
+	// is shorter and returns ErrLengthMismatch if the source is longer (default)
+	ArrayLengthZeroPad ArrayLengthPolicy = iota
+	// ArrayLengthTruncate drops the items which don't fit into the array,
+	// the shorter source is padded by zero values
+	ArrayLengthTruncate
+	// ArrayLengthStrict returns ErrLengthMismatch if the lengths differ
+	ArrayLengthStrict
+)
+
+type arrayLengthKey struct{}
+
+// ContextWithArrayLength returns the context with the length policy used by
+// the conversions into arrays by TryCastContext, TryToAnySliceContext,
+// TryCopyStructContext for array fields and map conversions for array values
+//
+//	ctx = gocast.ContextWithArrayLength(ctx, gocast.ArrayLengthStrict)
+//	rgb, err := gocast.TryCastContext[[3]uint8](ctx, []any{255, 128, 0})
+func ContextWithArrayLength(ctx context.Context, policy ArrayLengthPolicy) context.Context {
+	return context.WithValue(ctx, arrayLengthKey{}, policy)
+}
+
+// arrayLengthPolicy returns the length policy of the context
+func arrayLengthPolicy(ctx context.Context) ArrayLengthPolicy {
+	policy, _ := ctx.Value(arrayLengthKey{}).(ArrayLengthPolicy)
+	return policy
+}
+
+// arrayItemsCount returns the number of the source items converted into the array
+// by the length policy of the context
+func arrayItemsCount(ctx context.Context, arrayLen, srcLen int) (int, error) {
+	if srcLen == arrayLen {
+		return srcLen, nil
+	}
+	switch policy := arrayLengthPolicy(ctx); {
+	case policy == ArrayLengthStrict, srcLen > arrayLen && policy != ArrayLengthTruncate:
+		return 0, lengthMismatchError(arrayLen, srcLen)
+	}
+	if srcLen > arrayLen {
+		return arrayLen, nil
+	}
+	return srcLen, nil
+}
+
+// zeroArrayTail resets the array items starting from the index
+func zeroArrayTail(arr reflect.Value, from int) {
+	zero := reflect.Zero(arr.Type().Elem())
+	for i := from; i < arr.Len(); i++ {
+		arr.Index(i).Set(zero)
+	}
+}
+
+func lengthMismatchError(arrayLen, srcLen int) error {
+	return wrapError(ErrLengthMismatch, "array length "+strconv.Itoa(arrayLen)+
+		", source length "+strconv.Itoa(srcLen))
+}
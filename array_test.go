@@ -0,0 +1,103 @@
+package gocast
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrayConversion(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		truncate = ContextWithArrayLength(ctx, ArrayLengthTruncate)
+		strict   = ContextWithArrayLength(ctx, ArrayLengthStrict)
+	)
+
+	t.Run("slice to array", func(t *testing.T) {
+		res, err := TryCast[[3]int]([]string{"1", "2", "3"})
+		assert.NoError(t, err)
+		assert.Equal(t, [3]int{1, 2, 3}, res)
+		assert.Equal(t, [3]int{1, 2, 3}, Cast[[3]int]([]any{1, "2", 3.0}))
+	})
+
+	t.Run("array to slice", func(t *testing.T) {
+		res, err := TryCast[[]string]([2]int{1, 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "2"}, res)
+
+		arr, err := TryCast[[2]string]([2]int{1, 2})
+		assert.NoError(t, err)
+		assert.Equal(t, [2]string{"1", "2"}, arr)
+	})
+
+	t.Run("shorter source", func(t *testing.T) {
+		res, err := TryCast[[3]int]([]int{1})
+		assert.NoError(t, err)
+		assert.Equal(t, [3]int{1, 0, 0}, res)
+
+		res, err = TryCastContext[[3]int](truncate, []int{1})
+		assert.NoError(t, err)
+		assert.Equal(t, [3]int{1, 0, 0}, res)
+
+		_, err = TryCastContext[[3]int](strict, []int{1})
+		assert.ErrorIs(t, err, ErrLengthMismatch)
+	})
+
+	t.Run("longer source", func(t *testing.T) {
+		_, err := TryCast[[2]int]([]int{1, 2, 3})
+		assert.ErrorIs(t, err, ErrLengthMismatch)
+		assert.ErrorContains(t, err, "array length 2, source length 3")
+
+		res, err := TryCastContext[[2]int](truncate, []int{1, 2, 3})
+		assert.NoError(t, err)
+		assert.Equal(t, [2]int{1, 2}, res)
+
+		_, err = TryCastContext[[2]int](strict, []int{1, 2, 3})
+		assert.ErrorIs(t, err, ErrLengthMismatch)
+	})
+
+	t.Run("existing array", func(t *testing.T) {
+		arr := [4]int{9, 9, 9, 9}
+		assert.NoError(t, TryToAnySlice(&arr, []string{"1", "2"}))
+		assert.Equal(t, [4]int{1, 2, 0, 0}, arr)
+
+		assert.ErrorIs(t, TryToAnySlice(arr, []int{1}), ErrInvalidParams)
+		assert.ErrorIs(t, TryToAnySliceParallel(ctx, &arr, []int{1, 2, 3, 4, 5}, 2), ErrLengthMismatch)
+	})
+
+	t.Run("struct fields", func(t *testing.T) {
+		type point struct {
+			Coords [3]float64 `json:"coords"`
+			Tags   []string   `json:"tags"`
+		}
+		var p point
+		err := TryCopyStructContext(ctx, &p, map[string]any{
+			"coords": []any{"1.5", 2, 3},
+			"tags":   [2]any{"a", 1},
+		}, "json")
+		assert.NoError(t, err)
+		assert.Equal(t, point{Coords: [3]float64{1.5, 2, 3}, Tags: []string{"a", "1"}}, p)
+
+		err = TryCopyStruct(&p, map[string]any{"coords": []int{1, 2, 3, 4}}, "json")
+		assert.ErrorIs(t, err, ErrLengthMismatch)
+		assert.ErrorContains(t, err, "Coords")
+
+		err = TryCopyStructContext(truncate, &p, map[string]any{"coords": []int{1, 2, 3, 4}}, "json")
+		assert.NoError(t, err)
+		assert.Equal(t, [3]float64{1, 2, 3}, p.Coords)
+	})
+
+	t.Run("map values", func(t *testing.T) {
+		res, err := TryMap[string, [2]int](map[string]any{"a": []string{"1", "2"}})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][2]int{"a": {1, 2}}, res)
+
+		_, err = TryMap[string, [2]int](map[string]any{"a": []int{1, 2, 3}})
+		assert.ErrorIs(t, err, ErrLengthMismatch)
+
+		dst := map[string][1]string{}
+		assert.NoError(t, ToMapContext(truncate, dst, map[string][]int{"a": {1, 2}}, false))
+		assert.Equal(t, map[string][1]string{"a": {"1"}}, dst)
+	})
+}
@@ -127,5 +127,8 @@ func Bool(v any) bool {
 	case []any:
 		return len(bv) != 0
 	}
+	if nv, ok := unwrapSQLNull(v); ok {
+		return Bool(nv)
+	}
 	return ReflectToBool(reflect.ValueOf(v))
 }
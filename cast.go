@@ -99,10 +99,28 @@ func ReflectTryToTypeContext(ctx context.Context, srcVal reflect.Value, t reflec
 			k != reflect.Interface && k != reflect.Pointer {
 			return v.Interface(), nil
 		}
+	} else if res, ok, err := castGetReflect(ctx, v, t); err != nil {
+		return nil, err
+	} else if ok {
+		return ReflectTryToTypeContext(ctx, reflect.ValueOf(res), t, recursive, tags...)
+	} else if isSQLNullType(v.Type()) && t.Kind() != reflect.Interface {
+		// Unwrap sql.Null* source, NULL values are converted into nil or zero values
+		switch nv := sqlNullValue(v); {
+		case nv.IsValid():
+			return ReflectTryToTypeContext(ctx, nv, t, recursive, tags...)
+		case t.Kind() == reflect.Pointer || t.Kind() == reflect.Map || t.Kind() == reflect.Slice:
+			return nil, nil
+		}
+	}
+	if info := enumOf(t); info != nil && v.Type() != t {
+		return info.parse(v, t)
 	}
 	var err error
 	switch t.Kind() {
 	case reflect.String:
+		if name, ok := enumName(v.Interface()); ok {
+			return name, nil
+		}
 		if stringer, _ := srcVal.Interface().(fmt.Stringer); stringer != nil {
 			return stringer.String(), nil
 		}
@@ -134,6 +152,10 @@ func ReflectTryToTypeContext(ctx context.Context, srcVal reflect.Value, t reflec
 	case reflect.Float64:
 		return TryNumber[float64](v.Interface())
 	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Chan {
+			// Reading of the channel is allowed only by the explicit TryToAnySliceContext call
+			return nil, wrapError(ErrUnsupportedSourceType, v.Type().String())
+		}
 		slice := reflect.New(t)
 		if err = TryToAnySliceContext(ctx, slice.Interface(), v.Interface(), tags...); err == nil {
 			return slice.Elem().Interface(), nil
@@ -156,7 +178,19 @@ func ReflectTryToTypeContext(ctx context.Context, srcVal reflect.Value, t reflec
 				return newVal.Interface(), nil
 			}
 		} else if vl, err = ReflectTryToTypeContext(ctx, v, tElem, true, tags...); err == nil {
-			return reflect.ValueOf(vl).Addr().Interface(), nil
+			newVal := reflect.New(tElem)
+			if vl != nil {
+				val := reflect.ValueOf(vl)
+				if val.Type() != tElem {
+					// Numbers and strings are returned by the kind of the named type
+					if !val.CanConvert(tElem) {
+						return nil, wrapError(ErrUnsupportedType, tElem.String())
+					}
+					val = val.Convert(tElem)
+				}
+				newVal.Elem().Set(val)
+			}
+			return newVal.Interface(), nil
 		}
 	case reflect.Struct:
 		newVal := reflect.New(t)
@@ -0,0 +1,56 @@
+package gocast
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// CastGetter interface allows the source type to control how it is converted
+// into the specific target type.
+// Return ErrUnsupportedType to fall back to the default conversion.
+type CastGetter interface {
+	CastGet(ctx context.Context, target reflect.Type) (any, error)
+}
+
+// castGet returns the value exported by the CastGetter source for the target type.
+// The ok flag is false if the source doesn't implement CastGetter or declines the target.
+func castGet(ctx context.Context, v any, t reflect.Type) (res any, ok bool, err error) {
+	getter, _ := v.(CastGetter)
+	if getter == nil {
+		return nil, false, nil
+	}
+	if res, err = getter.CastGet(ctx, t); err != nil {
+		if errors.Is(err, ErrUnsupportedType) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	// Prevent endless recursion if the getter returns itself for a foreign type
+	if rt := reflect.TypeOf(res); rt != nil && rt != t && rt == reflect.TypeOf(v) {
+		return nil, false, nil
+	}
+	return res, true, nil
+}
+
+// castGetReflect is the same as castGet but also checks methods of the addressable value
+func castGetReflect(ctx context.Context, v reflect.Value, t reflect.Type) (any, bool, error) {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, false, nil
+	}
+	if _, ok := v.Interface().(CastGetter); ok {
+		return castGet(ctx, v.Interface(), t)
+	}
+	if v.Kind() != reflect.Pointer && v.CanAddr() {
+		return castGet(ctx, v.Addr().Interface(), t)
+	}
+	return nil, false, nil
+}
+
+// getCastValue returns the value exported by CastGetter or driver.Valuer source
+func getCastValue(ctx context.Context, v any, t reflect.Type) (any, error) {
+	if res, ok, err := castGet(ctx, v, t); err != nil || ok {
+		return res, err
+	}
+	return getValue(v), nil
+}
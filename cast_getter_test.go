@@ -0,0 +1,110 @@
+package gocast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMoney int64
+
+func (m testMoney) CastGet(ctx context.Context, t reflect.Type) (any, error) {
+	switch t.Kind() {
+	case reflect.String, reflect.Interface:
+		return fmt.Sprintf("%d.%02d USD", m/100, m%100), nil
+	case reflect.Float32, reflect.Float64:
+		return float64(m) / 100, nil
+	case reflect.Int, reflect.Int64:
+		return int64(m) / 100, nil
+	case reflect.Map, reflect.Struct:
+		return map[string]any{"amount": int64(m), "currency": "USD"}, nil
+	}
+	return nil, ErrUnsupportedType
+}
+
+type testBrokenGetter struct{}
+
+func (testBrokenGetter) CastGet(ctx context.Context, t reflect.Type) (any, error) {
+	return nil, errors.New("broken getter")
+}
+
+func TestCastGetter(t *testing.T) {
+	money := testMoney(1250)
+
+	t.Run("string", func(t *testing.T) {
+		assert.Equal(t, "12.50 USD", Str(money))
+		assert.Equal(t, "12.50 USD", Cast[string](money))
+		assert.Equal(t, "12.50 USD", Cast[string](&money))
+	})
+
+	t.Run("number", func(t *testing.T) {
+		assert.Equal(t, 12.5, Number[float64](money))
+		assert.Equal(t, 12, Number[int](money))
+		assert.Equal(t, int64(12), Cast[int64](money))
+		assert.Equal(t, float32(12.5), Cast[float32](money))
+	})
+
+	t.Run("declined", func(t *testing.T) {
+		_, err := TryNumber[uint8](money)
+		assert.ErrorIs(t, err, ErrUnsupportedNumericType)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		m := map[string]any{}
+		assert.NoError(t, ToMap(m, money, false))
+		assert.Equal(t, map[string]any{"amount": int64(1250), "currency": "USD"}, m)
+
+		type order struct {
+			Total testMoney
+			fee   testMoney
+		}
+		m, err := TryMap[string, any](order{Total: money, fee: money})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"Total": "12.50 USD"}, m)
+	})
+
+	t.Run("struct_field_to_map", func(t *testing.T) {
+		type order struct {
+			ID    int       `json:"id"`
+			Total testMoney `json:"total"`
+		}
+		m, err := TryMap[string, any](order{ID: 1, Total: money}, "json")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"id": 1, "total": "12.50 USD"}, m)
+
+		mAny := map[any]any{}
+		assert.NoError(t, ToMap(mAny, order{ID: 1, Total: money}, false, "json"))
+		assert.Equal(t, "12.50 USD", mAny["total"])
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type amount struct {
+			Amount   int64  `json:"amount"`
+			Currency string `json:"currency"`
+		}
+		var res amount
+		assert.NoError(t, TryCopyStruct(&res, money, "json"))
+		assert.Equal(t, amount{Amount: 1250, Currency: "USD"}, res)
+
+		type invoice struct {
+			Total    amount `json:"total"`
+			TotalStr string `json:"total_str"`
+		}
+		var inv invoice
+		assert.NoError(t, TryCopyStruct(&inv, map[string]any{"total": money, "total_str": money}, "json"))
+		assert.Equal(t, amount{Amount: 1250, Currency: "USD"}, inv.Total)
+		assert.Equal(t, "12.50 USD", inv.TotalStr)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := TryStr(testBrokenGetter{})
+		assert.EqualError(t, err, "broken getter")
+		_, err = TryCast[int](testBrokenGetter{})
+		assert.EqualError(t, err, "broken getter")
+		assert.Error(t, ToMap(map[string]any{}, testBrokenGetter{}, false))
+	})
+}
@@ -119,6 +119,22 @@ func TestReflectTryToType(t *testing.T) {
 	})
 }
 
+func TestCastNamedTypePointer(t *testing.T) {
+	type myInt int
+	type myStr string
+
+	v, err := TryCast[*myInt]("5")
+	if assert.NoError(t, err) {
+		assert.Equal(t, myInt(5), *v)
+	}
+	s, err := TryCast[*myStr](10)
+	if assert.NoError(t, err) {
+		assert.Equal(t, myStr("10"), *s)
+	}
+	_, err = TryCast[*myInt]("x")
+	assert.Error(t, err)
+}
+
 func TestTryCastValue(t *testing.T) {
 	t.Run("non-recursive int", func(t *testing.T) {
 		v, err := TryCastValue[int]("55", false)
@@ -149,3 +165,59 @@ func TestTryCastRecursive(t *testing.T) {
 		assert.Equal(t, 7, v.X)
 	})
 }
+
+type testCancelKey struct{}
+
+// testCancelItem cancels the context of the conversion on the value 100
+type testCancelItem struct{ Value int }
+
+func (it *testCancelItem) CastSet(ctx context.Context, v any) error {
+	if it.Value = Number[int](v); it.Value == 100 {
+		ctx.Value(testCancelKey{}).(context.CancelFunc)()
+	}
+	return nil
+}
+
+func TestConversionContextCancel(t *testing.T) {
+	items := make([]any, 1000)
+	for i := range items {
+		items[i] = i
+	}
+	cancelCtx := func() context.Context {
+		ctx, cancel := context.WithCancel(context.Background())
+		return context.WithValue(ctx, testCancelKey{}, cancel)
+	}
+
+	t.Run("slice", func(t *testing.T) {
+		_, err := TryAnySliceContext[testCancelItem](cancelCtx(), items)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.EqualError(t, err, "[128]: context canceled")
+
+		_, err = TrySliceContext[testCancelItem](cancelCtx(), items)
+		assert.EqualError(t, err, "[128]: context canceled")
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type listType struct {
+			Name  string           `json:"name"`
+			Items []testCancelItem `json:"items"`
+		}
+		var list listType
+		err := TryCopyStructContext(cancelCtx(), &list, map[string]any{"name": "list", "items": items}, "json")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.EqualError(t, err, "Items: [128]: context canceled")
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := TryMapContext[string, any](ctx, map[int]int{1: 1})
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = TryCastContext[map[string]any](ctx, struct{ ID int }{ID: 1})
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = TryCastContext[struct{ ID int }](ctx, map[string]any{"ID": 1})
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = TryCastContext[map[int]int](ctx, map[string]any{"1": 1})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,593 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const gocastImport = "github.com/demdxx/gocast/v2"
+
+// contextCheckInterval is the number of fields between the checks of the context like in gocast
+const contextCheckInterval = 64
+
+// fieldNameArr is the list of tags used by gocast if the tag is not defined
+var fieldNameArr = []string{"field", "schema", "sql", "json", "xml", "yaml"}
+
+// basicTypes are copied by the assignment in DeepCopy
+var basicTypes = map[string]bool{
+	"bool": true, "string": true, "byte": true, "rune": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+}
+
+type options struct {
+	types  []string
+	tags   []string
+	output string
+}
+
+type structField struct {
+	name     string
+	typ      string
+	tag      reflect.StructTag
+	exported bool
+	expr     ast.Expr
+}
+
+type structType struct {
+	name     string
+	fields   []structField
+	generate bool
+	converts []string
+	imports  map[string]string // Package name -> import path used by the exported fields
+}
+
+type typeDecl struct {
+	expr    ast.Expr
+	imports map[string]string // Package name -> import path of the file
+}
+
+type generator struct {
+	opts    options
+	pkg     string
+	structs map[string]*structType
+	types   map[string]typeDecl // Named types of the package
+	imports map[string]string   // Import path -> package name
+	buf     bytes.Buffer
+}
+
+// generate returns the source of converters for the package in the directory
+func generate(dir string, opts options) ([]byte, error) {
+	g := &generator{
+		opts:    opts,
+		structs: map[string]*structType{},
+		types:   map[string]typeDecl{},
+		imports: map[string]string{},
+	}
+	if err := g.parse(dir); err != nil {
+		return nil, err
+	}
+	for _, name := range opts.types {
+		st, ok := g.structs[name]
+		if !ok {
+			return nil, fmt.Errorf("struct type %s is not found", name)
+		}
+		st.generate = true
+	}
+
+	names := make([]string, 0, len(g.structs))
+	for name, st := range g.structs {
+		if st.generate {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no types to generate in %s", dir)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := g.generateType(g.structs[name]); err != nil {
+			return nil, err
+		}
+	}
+	return g.source()
+}
+
+func (g *generator) parse(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+	fset := token.NewFileSet()
+	for _, filename := range files {
+		if strings.HasSuffix(filename, "_test.go") || filepath.Base(filename) == g.opts.output {
+			continue
+		}
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		file, err := parser.ParseFile(fset, filename, data, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		g.pkg = file.Name.Name
+		if err := g.parseFile(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) parseFile(file *ast.File) error {
+	fileImports := map[string]string{}
+	for _, imp := range file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := importName(path)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		fileImports[name] = path
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			g.types[ts.Name.Name] = typeDecl{expr: ts.Type, imports: fileImports}
+			sType, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			doc := ts.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			st := &structType{name: ts.Name.Name, imports: map[string]string{}}
+			if doc != nil {
+				for _, comment := range doc.List {
+					text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+					switch {
+					case text == "gocast:generate":
+						st.generate = true
+					case strings.HasPrefix(text, "gocast:convert "):
+						st.generate = true
+						st.converts = append(st.converts, strings.Fields(strings.TrimPrefix(text, "gocast:convert "))...)
+					}
+				}
+			}
+			if ts.TypeParams != nil && st.generate {
+				return fmt.Errorf("%s: generic types are not supported", st.name)
+			}
+			for _, field := range sType.Fields.List {
+				if len(field.Names) == 0 {
+					if st.generate {
+						return fmt.Errorf("%s: embedded fields are not supported", st.name)
+					}
+					continue
+				}
+				var tag reflect.StructTag
+				if field.Tag != nil {
+					value, _ := strconv.Unquote(field.Tag.Value)
+					tag = reflect.StructTag(value)
+				}
+				if isExportedField(field) {
+					ast.Inspect(field.Type, func(n ast.Node) bool {
+						if sel, ok := n.(*ast.SelectorExpr); ok {
+							if x, ok := sel.X.(*ast.Ident); ok {
+								st.imports[x.Name] = fileImports[x.Name]
+							}
+							return false
+						}
+						return true
+					})
+				}
+				for _, name := range field.Names {
+					st.fields = append(st.fields, structField{
+						name:     name.Name,
+						typ:      types.ExprString(field.Type),
+						tag:      tag,
+						exported: name.IsExported(),
+						expr:     field.Type,
+					})
+				}
+			}
+			g.structs[st.name] = st
+		}
+	}
+	return nil
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) generateType(st *structType) error {
+	for pkgName, path := range st.imports {
+		if path == "" {
+			return fmt.Errorf("%s: unknown package %s", st.name, pkgName)
+		}
+		g.imports[path] = pkgName
+	}
+	for _, f := range st.fields {
+		if f.tag.Get("enum") != "" {
+			return fmt.Errorf("%s.%s: enum tag is not supported, use gocast.RegisterEnum", st.name, f.name)
+		}
+		if f.exported && g.hasLock(f.expr, g.types[st.name].imports, map[string]bool{}) {
+			return fmt.Errorf("%s.%s: exported fields holding locks are not supported", st.name, f.name)
+		}
+	}
+
+	// The values holding locks must not be copied, the methods use the pointer receiver
+	recv := st.name
+	locked := g.hasLock(ast.NewIdent(st.name), nil, map[string]bool{})
+	if locked {
+		recv = "*" + st.name
+	}
+
+	g.printf("// GocastTags returns the tags used to generate the converters of %s\n", st.name)
+	g.printf("func (%s) GocastTags() []string { return %s }\n\n", recv, g.tagsLiteral())
+
+	g.generateCopyFrom(st)
+	g.generateToMap(st, recv)
+	if !locked && !g.isRecursive(st) {
+		g.generateDeepCopy(st)
+	}
+	for _, target := range st.converts {
+		dst, ok := g.structs[target]
+		if !ok {
+			return fmt.Errorf("%s: convert target %s is not a struct of the package", st.name, target)
+		}
+		if g.hasLock(ast.NewIdent(dst.name), nil, map[string]bool{}) {
+			return fmt.Errorf("%s: convert target %s holds locks", st.name, target)
+		}
+		g.generateConvert(st, dst, recv)
+	}
+	return nil
+}
+
+// hasLock returns true if the value of the type holds the sync or sync/atomic value
+// which must not be copied, the pointers, slices and maps are not checked
+func (g *generator) hasLock(expr ast.Expr, imports map[string]string, seen map[string]bool) bool {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return g.hasLock(e.X, imports, seen)
+	case *ast.ArrayType:
+		return e.Len != nil && g.hasLock(e.Elt, imports, seen)
+	case *ast.StructType:
+		for _, field := range e.Fields.List {
+			if g.hasLock(field.Type, imports, seen) {
+				return true
+			}
+		}
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok {
+			path := imports[x.Name]
+			return path == "sync" || path == "sync/atomic"
+		}
+	case *ast.Ident:
+		if decl, ok := g.types[e.Name]; ok && !seen[e.Name] {
+			seen[e.Name] = true
+			return g.hasLock(decl.expr, decl.imports, seen)
+		}
+	}
+	return false
+}
+
+// isRecursive returns true if the value of the type can refer to the value of the same type:
+// the type is on the cycle of the package types or reaches the interface field.
+// The generated DeepCopy starts the new copy for every field, so such types
+// are copied by the reflection which tracks the visited pointers.
+func (g *generator) isRecursive(st *structType) bool {
+	seen := map[string]bool{}
+	var visit func(expr ast.Expr) bool
+	visit = func(expr ast.Expr) (found bool) {
+		ast.Inspect(expr, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.SelectorExpr, *ast.FuncType:
+				return false
+			case *ast.InterfaceType:
+				found = true
+			case *ast.Ident:
+				decl, ok := g.types[n.Name]
+				switch {
+				case n.Name == "any" || n.Name == "error" || n.Name == st.name:
+					found = true
+				case ok && !seen[n.Name]:
+					seen[n.Name] = true
+					found = visit(decl.expr)
+				}
+			}
+			return !found
+		})
+		return found
+	}
+	return visit(g.types[st.name].expr)
+}
+
+func (g *generator) generateCopyFrom(st *structType) {
+	var fields []structField
+	needOk := false
+	for _, f := range st.fields {
+		if f.exported {
+			fields = append(fields, f)
+			needOk = needOk || isOptionalType(f.typ) || len(uniqueStrings(fieldNames(f, g.opts.tags))) > 1
+		}
+	}
+	g.imports["context"] = "context"
+	g.printf("// CopyFrom puts the values of the map into the fields of %s\n", st.name)
+	g.printf("func (v *%s) CopyFrom(src map[string]any) error {\n", st.name)
+	g.printf("return v.CopyFromContext(context.Background(), src)\n}\n\n")
+	g.printf("// CopyFromContext puts the values of the map into the fields of %s,\n", st.name)
+	g.printf("// the options of the context are used by the conversions of the fields\n")
+	g.printf("func (v *%s) CopyFromContext(ctx context.Context, src map[string]any) (err error) {\n", st.name)
+	switch {
+	case len(fields) == 0:
+	case needOk:
+		g.printf("var (\nval any\nok bool\n)\n")
+	default:
+		g.printf("var val any\n")
+	}
+	for i, f := range fields {
+		g.imports[gocastImport] = "gocast"
+		g.imports["fmt"] = "fmt"
+		if i%contextCheckInterval == 0 {
+			// The context is checked like by TryCopyStructContext
+			g.printf("if err = ctx.Err(); err != nil {\nreturn fmt.Errorf(\"%s: %%w\", err)\n}\n", f.name)
+		}
+		keys := uniqueStrings(fieldNames(f, g.opts.tags))
+		if needOk {
+			g.printf("val, ok = src[%q]\n", keys[0])
+		} else {
+			g.printf("val = src[%q]\n", keys[0])
+		}
+		for _, key := range keys[1:] {
+			g.printf("if !ok {\nval, ok = src[%q]\n}\n", key)
+		}
+		if isOptionalType(f.typ) {
+			g.printf("if val == nil && ok {\nv.%s.SetNull()\n} else ", f.name)
+		}
+		g.printf("if v.%s, err = gocast.TryCastContext[%s](ctx, val%s); err != nil {\n", f.name, f.typ, g.tagsArgs())
+		g.printf("return fmt.Errorf(\"%s: %%w\", err)\n}\n", f.name)
+	}
+	g.printf("return nil\n}\n\n")
+}
+
+func (g *generator) generateToMap(st *structType, recv string) {
+	g.imports[gocastImport] = "gocast"
+	g.printf("// ToMap returns the map of the fields of %s\n", st.name)
+	g.printf("func (v %s) ToMap() map[string]any {\n", recv)
+	g.printf("m := make(map[string]any, %d)\n", len(st.fields))
+	for _, f := range st.fields {
+		if !f.exported {
+			continue
+		}
+		name, omitempty := fieldNameFromTags(f, g.opts.tags)
+		if omitempty && isOptionalType(f.typ) {
+			g.printf("if v.%s.IsSet() {\nm[%q] = v.%s\n}\n", f.name, name, f.name)
+		} else if omitempty {
+			g.printf("if !gocast.IsEmpty(v.%s) {\nm[%q] = v.%s\n}\n", f.name, name, f.name)
+		} else {
+			g.printf("m[%q] = v.%s\n", name, f.name)
+		}
+	}
+	g.printf("return m\n}\n\n")
+}
+
+// generateDeepCopy writes DeepCopy which copies the fields like TryCopy:
+// the copy tags are used and the fields referring to the same value refer to the same copy
+func (g *generator) generateDeepCopy(st *structType) {
+	g.printf("// DeepCopy returns the deep copy of %s\n", st.name)
+	g.printf("func (v %s) DeepCopy() (dst %s, err error) {\n", st.name, st.name)
+	hasState := false
+	for _, f := range st.fields {
+		tag := f.tag.Get("copy")
+		switch {
+		case tag == "-":
+			// The field is left zero
+		case tag == "shallow" || basicTypes[f.typ]:
+			g.printf("dst.%s = v.%s\n", f.name, f.name)
+		default:
+			action := "gocast.CopyActionDefault"
+			if tag == "deep" {
+				action = "gocast.CopyActionDeep"
+			}
+			if !hasState {
+				g.imports[gocastImport] = "gocast"
+				g.printf("var state gocast.CopyState\n")
+				hasState = true
+			}
+			g.printf("if dst.%s, err = gocast.CopyField(&state, v.%s, %s, %t); err != nil {\n", f.name, f.name, action, f.exported)
+			g.printf("return dst, err\n}\n")
+		}
+	}
+	g.printf("return dst, nil\n}\n\n")
+}
+
+func (g *generator) generateConvert(src, dst *structType, recv string) {
+	srcFields := map[string]structField{}
+	for _, f := range src.fields {
+		if f.exported {
+			srcFields[f.name] = f
+		}
+	}
+	g.printf("// To%s converts %s into %s\n", dst.name, src.name, dst.name)
+	g.printf("func (v %s) To%s() (dst %s, err error) {\n", recv, dst.name, dst.name)
+	for _, f := range dst.fields {
+		if !f.exported {
+			continue
+		}
+		for _, name := range fieldNames(f, g.opts.tags) {
+			sf, ok := srcFields[name]
+			if !ok {
+				continue
+			}
+			if sf.typ == f.typ {
+				g.printf("dst.%s = v.%s\n", f.name, sf.name)
+			} else {
+				g.imports[gocastImport] = "gocast"
+				g.imports["fmt"] = "fmt"
+				g.printf("if dst.%s, err = gocast.TryCast[%s](v.%s%s); err != nil {\n", f.name, f.typ, sf.name, g.tagsArgs())
+				g.printf("return dst, fmt.Errorf(\"%s: %%w\", err)\n}\n", f.name)
+			}
+			break
+		}
+	}
+	g.printf("return dst, nil\n}\n\n")
+}
+
+// source returns the formatted file with the package clause and imports
+func (g *generator) source() ([]byte, error) {
+	var (
+		out      bytes.Buffer
+		std, ext []string
+	)
+	for path, name := range g.imports {
+		line := strconv.Quote(path)
+		if name != importName(path) {
+			line = name + " " + line
+		}
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			ext = append(ext, line)
+		} else {
+			std = append(std, line)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(ext)
+
+	fmt.Fprintf(&out, "// Code generated by gocastgen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", g.pkg)
+	for _, line := range std {
+		fmt.Fprintf(&out, "%s\n", line)
+	}
+	if len(std) > 0 && len(ext) > 0 {
+		out.WriteString("\n")
+	}
+	for _, line := range ext {
+		fmt.Fprintf(&out, "%s\n", line)
+	}
+	out.WriteString(")\n\n")
+	out.Write(g.buf.Bytes())
+	return format.Source(out.Bytes())
+}
+
+func (g *generator) tagsLiteral() string {
+	if len(g.opts.tags) == 0 {
+		return "nil"
+	}
+	return "[]string{" + g.tagsArgs()[2:] + "}"
+}
+
+func (g *generator) tagsArgs() string {
+	var args strings.Builder
+	for _, tag := range g.opts.tags {
+		args.WriteString(", " + strconv.Quote(tag))
+	}
+	return args.String()
+}
+
+// importName returns the default package name of the import path, the major version suffix is skipped
+func importName(path string) string {
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	if len(parts) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = parts[len(parts)-2]
+	}
+	return name
+}
+
+// isExportedField returns true if one of the names of the field is exported
+func isExportedField(field *ast.Field) bool {
+	for _, name := range field.Names {
+		if name.IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+func isOptionalType(typ string) bool {
+	return strings.HasPrefix(typ, "gocast.Optional[") || strings.HasPrefix(typ, "Optional[")
+}
+
+func uniqueStrings(list []string) []string {
+	res := list[:0:0]
+	for _, s := range list {
+		found := false
+		for _, r := range res {
+			found = found || r == s
+		}
+		if !found {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// fieldNames returns the source keys of the field like gocast fieldNames
+func fieldNames(f structField, tags []string) []string {
+	if len(tags) > 0 {
+		name := strings.Split(fieldTag(f, tags[0]), ",")[0]
+		if name == "" || name == "-" {
+			return []string{f.name}
+		}
+		return []string{name, f.name}
+	}
+	return []string{f.name, f.name}
+}
+
+// fieldNameFromTags returns the map key of the field like gocast fieldNameFromTags
+func fieldNameFromTags(f structField, tags []string) (name string, omitempty bool) {
+	if len(tags) == 0 {
+		return f.name, false
+	}
+	names := strings.Split(fieldTag(f, tags[0]), ",")
+	name = names[0]
+	if len(names) > 1 && names[len(names)-1] == "omitempty" {
+		omitempty = true
+	}
+	if name == "" {
+		name = f.name
+	}
+	return name, omitempty
+}
+
+// fieldTag returns the tag value of the field like gocast fieldTag
+func fieldTag(f structField, tag string) string {
+	if tag == "-" {
+		return f.name
+	}
+	tags := fieldNameArr
+	if tag != "" {
+		tags = strings.Split(tag, ",")
+	}
+	var value string
+	for _, k := range tags {
+		if value = f.tag.Get(k); value != "" {
+			break
+		}
+	}
+	switch value {
+	case "":
+		return f.name
+	case "-":
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	const dir = "../../internal/gentest"
+	src, err := generate(dir, options{tags: []string{"json"}, output: "gocast_gen.go"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	golden, err := os.ReadFile(filepath.Join(dir, "gocast_gen.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(golden), string(src), "run go generate ./internal/gentest")
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		opts options
+	}{
+		{
+			name: "unknown type",
+			src:  "package p\n\ntype A struct{ Name string }\n",
+			opts: options{types: []string{"B"}},
+		},
+		{
+			name: "embedded field",
+			src:  "package p\n\ntype B struct{}\n\n//gocast:generate\ntype A struct{ B }\n",
+		},
+		{
+			name: "generic type",
+			src:  "package p\n\n//gocast:generate\ntype A[T any] struct{ V T }\n",
+		},
+		{
+			name: "enum tag",
+			src:  "package p\n\n//gocast:generate\ntype A struct{ V string `enum:\"a,b\"` }\n",
+		},
+		{
+			name: "unknown convert target",
+			src:  "package p\n\n//gocast:generate\n//gocast:convert B\ntype A struct{ V string }\n",
+		},
+		{
+			name: "exported lock field",
+			src:  "package p\n\nimport \"sync\"\n\n//gocast:generate\ntype A struct{ Mu sync.Mutex }\n",
+		},
+		{
+			name: "convert target with lock",
+			src:  "package p\n\nimport \"sync\"\n\ntype B struct{ mu [1]sync.RWMutex }\n\n//gocast:generate\n//gocast:convert B\ntype A struct{ V string }\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, "p.go"), []byte(test.src), 0o644))
+			test.opts.output = "gocast_gen.go"
+			_, err := generate(dir, test.opts)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGenerateDeepCopy(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		deepCopy bool
+	}{
+		{
+			name:     "plain",
+			src:      "type B struct{ V []int }\n\n//gocast:generate\ntype A struct{ B B; P *B }\n",
+			deepCopy: true,
+		},
+		{
+			name: "mutual recursion",
+			src:  "type B struct{ A []*A }\n\n//gocast:generate\ntype A struct{ B map[string]B }\n",
+		},
+		{
+			name: "interface field",
+			src:  "type I interface{ M() }\n\ntype B struct{ I I }\n\n//gocast:generate\ntype A struct{ B *B }\n",
+		},
+		{
+			name: "lock",
+			src:  "import \"sync\"\n\ntype B struct{ mu sync.Mutex }\n\n//gocast:generate\ntype A struct{ b [2]B }\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, "p.go"), []byte("package p\n\n"+test.src), 0o644))
+			src, err := generate(dir, options{output: "gocast_gen.go"})
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.deepCopy, strings.Contains(string(src), "func (v A) DeepCopy()"))
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Command gocastgen generates reflection-free converters for struct types.
+//
+// The types are selected by the `//gocast:generate` comment or the -type flag.
+// For every type the tool generates the methods which gocast detects and uses
+// instead of the reflection:
+//
+//   - CopyFrom(map[string]any) error and CopyFromContext(ctx, map[string]any) error —
+//     used by TryCopyStruct for map[string]any sources with the options of the context
+//   - ToMap() map[string]any — used by ToMap and TryMapCopy for struct sources
+//   - DeepCopy() (T, error) — used by TryCopy (not generated for self-referencing types)
+//   - GocastTags() []string — the tags of the generation, the generated methods
+//     are used only by the calls with the same tags
+//
+// The `//gocast:convert Target` comment generates `ToTarget() (Target, error)` converter
+// into another struct type of the same package.
+//
+// Usage:
+//
+//	//go:generate go run github.com/demdxx/gocast/v2/cmd/gocastgen -tags json
+//
+//	//gocast:generate
+//	//gocast:convert UserDTO
+//	type User struct {
+//	    ID   int64  `json:"id"`
+//	    Name string `json:"name,omitempty"`
+//	}
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		types  = flag.String("type", "", "comma-separated list of type names in addition to the annotated ones")
+		tags   = flag.String("tags", "", "comma-separated list of struct tags used to name the fields")
+		output = flag.String("output", "gocast_gen.go", "output file name")
+	)
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	opts := options{output: *output}
+	if *types != "" {
+		opts.types = strings.Split(*types, ",")
+	}
+	if *tags != "" {
+		opts.tags = strings.Split(*tags, ",")
+	}
+
+	src, err := generate(dir, opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gocastgen:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(filepath.Join(dir, opts.output), src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "gocastgen:", err)
+		os.Exit(1)
+	}
+}
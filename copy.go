@@ -1,7 +1,13 @@
 package gocast
 
 import (
+	"context"
+	"errors"
+	"os"
 	"reflect"
+	"sync"
+	"time"
+	"unsafe"
 )
 
 // TryCopy creates a deep copy of the provided value using reflection or
@@ -9,6 +15,12 @@ import (
 // It returns a new value of the same type as the source.
 // If the source value is nil, it returns the zero value of the type.
 func TryCopy[T any](src T) (T, error) {
+	return TryCopyContext(context.Background(), src)
+}
+
+// TryCopyContext creates a deep copy like TryCopy and stops copying
+// with the error of the context if it's canceled
+func TryCopyContext[T any](ctx context.Context, src T) (T, error) {
 	var dst T
 
 	srcValue := reflect.ValueOf(src)
@@ -24,7 +36,8 @@ func TryCopy[T any](src T) (T, error) {
 	// Use a visited map to handle circular references
 	visited := make(map[uintptr]reflect.Value)
 
-	err := deepCopy(srcValue, reflect.ValueOf(&dst).Elem(), visited)
+	// The source is taken by the pointer to keep the interface type of T
+	err := deepCopy(ctx, reflect.ValueOf(&src).Elem(), reflect.ValueOf(&dst).Elem(), visited)
 	if err != nil {
 		return dst, err
 	}
@@ -49,7 +62,7 @@ func TryAnyCopy(src any) (any, error) {
 	visited := make(map[uintptr]reflect.Value)
 
 	dst := reflect.New(reflect.TypeOf(src)).Elem()
-	err := deepCopy(reflect.ValueOf(src), dst, visited)
+	err := deepCopy(context.Background(), reflect.ValueOf(src), dst, visited)
 	if err != nil {
 		return nil, err
 	}
@@ -71,9 +84,191 @@ type CopyOptions struct {
 	IgnoreUnexportedFields bool
 	MaxDepth               int
 	IgnoreCircularRefs     bool
+
+	// FieldFilter defines the copy action of the struct field, path contains
+	// names of the parent fields. CopyActionDefault falls back to the `copy` tag.
+	FieldFilter func(path []string, f reflect.StructField) CopyAction
+
+	path []string
+	// noShare copies deeply the values shared by the copy tags and CopyPolicyShare
+	noShare bool
+}
+
+// CopyAction defines how the struct field is copied
+type CopyAction int
+
+const (
+	// CopyActionDefault uses the `copy` tag or the default deep copy
+	CopyActionDefault CopyAction = iota
+	// CopyActionSkip leaves the zero value, the same as `copy:"-"` tag
+	CopyActionSkip
+	// CopyActionShallow shares the value (reference), the same as `copy:"shallow"` tag
+	CopyActionShallow
+	// CopyActionDeep copies the value recursively even if the type has a copy policy,
+	// the same as `copy:"deep"` tag
+	CopyActionDeep
+)
+
+// fieldCopyAction returns the copy action of the struct field from the `copy` tag
+func fieldCopyAction(f reflect.StructField) CopyAction {
+	switch f.Tag.Get("copy") {
+	case "-":
+		return CopyActionSkip
+	case "shallow":
+		return CopyActionShallow
+	case "deep":
+		return CopyActionDeep
+	}
+	return CopyActionDefault
+}
+
+// fieldCopyAction returns the copy action of the struct field from the FieldFilter or the `copy` tag
+func (opts CopyOptions) fieldCopyAction(f reflect.StructField) CopyAction {
+	action := CopyActionDefault
+	if opts.FieldFilter != nil {
+		action = opts.FieldFilter(opts.path[:len(opts.path):len(opts.path)], f)
+	}
+	if action == CopyActionDefault {
+		action = fieldCopyAction(f)
+	}
+	if action == CopyActionShallow && opts.noShare {
+		return CopyActionDeep
+	}
+	return action
+}
+
+// useCloner returns true if the options don't change the copy of the struct fields,
+// so the `Clone` or `DeepCopy` method of the type can be called instead
+func (opts CopyOptions) useCloner() bool {
+	return opts.FieldFilter == nil && opts.MaxDepth <= 0 &&
+		!opts.IgnoreUnexportedFields && !opts.noShare
+}
+
+// withField returns the options with the field name added to the path
+func (opts CopyOptions) withField(name string) CopyOptions {
+	opts.path = append(opts.path[:len(opts.path):len(opts.path)], name)
+	return opts
+}
+
+// CopyPolicy defines how values of the specific type are copied by TryCopy
+type CopyPolicy int
+
+const (
+	// CopyPolicyDeep copies the value recursively (default)
+	CopyPolicyDeep CopyPolicy = iota
+	// CopyPolicyShare keeps the same value (reference) in the copy
+	CopyPolicyShare
+	// CopyPolicyZero leaves the zero value in the copy
+	CopyPolicyZero
+)
+
+var copyPolicies sync.Map // map[reflect.Type]CopyPolicy
+
+func init() {
+	// Locks and other synchronization primitives must not be copied with their state
+	RegisterCopyPolicy[sync.Mutex](CopyPolicyZero)
+	RegisterCopyPolicy[sync.RWMutex](CopyPolicyZero)
+	RegisterCopyPolicy[sync.WaitGroup](CopyPolicyZero)
+	RegisterCopyPolicy[sync.Once](CopyPolicyZero)
+	RegisterCopyPolicy[sync.Map](CopyPolicyZero)
+	RegisterCopyPolicy[sync.Pool](CopyPolicyZero)
+	// System resources and immutable shared data
+	RegisterCopyPolicy[*os.File](CopyPolicyShare)
+	RegisterCopyPolicy[*time.Location](CopyPolicyShare)
+}
+
+// RegisterCopyPolicy sets the copy policy for all values of the type T.
+// Channels and functions can't be copied deeply, so without the policy
+// TryCopy returns ErrCopyUnsupportedType for them, unless they are held by
+// unexported fields which are shared with the source.
+//
+//	gocast.RegisterCopyPolicy[chan Event](gocast.CopyPolicyShare)
+//	gocast.RegisterCopyPolicy[*zap.Logger](gocast.CopyPolicyShare)
+func RegisterCopyPolicy[T any](policy CopyPolicy) {
+	copyPolicies.Store(reflect.TypeOf((*T)(nil)).Elem(), policy)
+}
+
+// copyPolicyOf returns the registered copy policy of the type
+func copyPolicyOf(t reflect.Type) CopyPolicy {
+	if policy, ok := copyPolicies.Load(t); ok {
+		return policy.(CopyPolicy)
+	}
+	return CopyPolicyDeep
+}
+
+// applyCopyPolicy puts the value by the type policy and returns true if the value is processed
+func applyCopyPolicy(src, dst reflect.Value) bool {
+	switch copyPolicyOf(src.Type()) {
+	case CopyPolicyShare:
+		dst.Set(src)
+	case CopyPolicyZero:
+		dst.Set(reflect.Zero(dst.Type()))
+	default:
+		return false
+	}
+	return true
+}
+
+var clonerMethods sync.Map // map[reflect.Type]int, -1 if there is no method
+
+// cloneValue calls `Clone() T` or `DeepCopy() T` method of the value if present,
+// the methods returning `(T, error)` like the ones generated by cmd/gocastgen are called too.
+// The method must not call TryCopy for the same value, it leads to endless recursion,
+// and it's responsible for the `copy` tags of the type fields.
+func cloneValue(src reflect.Value) (reflect.Value, bool, error) {
+	t := src.Type()
+	idx, ok := clonerMethods.Load(t)
+	if !ok {
+		idx = -1
+		for _, name := range []string{"Clone", "DeepCopy"} {
+			if m, ok := t.MethodByName(name); ok && isClonerMethod(m.Type, t) {
+				idx = m.Index
+				break
+			}
+		}
+		clonerMethods.Store(t, idx)
+	}
+	if idx.(int) < 0 || !src.CanInterface() || (src.Kind() == reflect.Pointer && src.IsNil()) {
+		return reflect.Value{}, false, nil
+	}
+	res := src.Method(idx.(int)).Call(nil)
+	if len(res) > 1 && !res[1].IsNil() {
+		return reflect.Value{}, true, res[1].Interface().(error)
+	}
+	return res[0], true, nil
 }
 
-// TryCopyWithOptions creates a deep copy with custom options
+// isClonerMethod returns true if the method type is `func() T` or `func() (T, error)`
+func isClonerMethod(mt, t reflect.Type) bool {
+	if mt.NumIn() != 1 || mt.NumOut() < 1 || mt.NumOut() > 2 || mt.Out(0) != t {
+		return false
+	}
+	return mt.NumOut() == 1 || mt.Out(1) == reflect.TypeOf((*error)(nil)).Elem()
+}
+
+// addressableValue returns the addressable value to access unexported fields of the struct
+func addressableValue(v reflect.Value) reflect.Value {
+	if v.CanAddr() || !v.CanInterface() {
+		return v
+	}
+	newVal := reflect.New(v.Type()).Elem()
+	newVal.Set(v)
+	return newVal
+}
+
+// accessibleField returns the field of the addressable struct which can be read and set
+// even if it is unexported
+func accessibleField(v reflect.Value, i int) reflect.Value {
+	field := v.Field(i)
+	if field.CanSet() || !v.CanAddr() {
+		return field
+	}
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+}
+
+// TryCopyWithOptions creates a deep copy with custom options.
+// The `Clone` and `DeepCopy` methods are not called if FieldFilter, MaxDepth
+// or IgnoreUnexportedFields is set, the options are applied to the fields instead.
 func TryCopyWithOptions[T any](src T, opts CopyOptions) (T, error) {
 	var dst T
 
@@ -98,7 +293,33 @@ func TryCopyWithOptions[T any](src T, opts CopyOptions) (T, error) {
 	return dst, nil
 }
 
-func deepCopy(src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
+// TryCopyInto deep copies the source value into the existing destination value.
+// Values of the destination which are not present in the source are reset.
+// The destination is left unchanged if the copy fails.
+func TryCopyInto[T any](dst *T, src T, opts CopyOptions) error {
+	if dst == nil {
+		return wrapError(ErrCopyInvalidValue, "nil destination")
+	}
+	var (
+		res     T
+		visited = make(map[uintptr]reflect.Value)
+	)
+	if err := deepCopyWithOptions(reflect.ValueOf(&src).Elem(), reflect.ValueOf(&res).Elem(), visited, opts, 0); err != nil {
+		return err
+	}
+	*dst = res
+	return nil
+}
+
+// CopyInto deep copies the source value into the existing destination value
+// and panics on error
+func CopyInto[T any](dst *T, src T, opts CopyOptions) {
+	if err := TryCopyInto(dst, src, opts); err != nil {
+		panic(err)
+	}
+}
+
+func deepCopy(ctx context.Context, src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
 	// Handle nil or invalid source values
 	if !src.IsValid() {
 		return nil
@@ -115,12 +336,35 @@ func deepCopy(src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
 		return nil
 	}
 
+	if dst.CanSet() && applyCopyPolicy(src, dst) {
+		return nil
+	}
+
+	return deepCopyValue(ctx, src, dst, visited)
+}
+
+// deepCopyValue copies the value recursively without the type copy policy check
+func deepCopyValue(ctx context.Context, src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
+	if !src.IsValid() {
+		return nil
+	}
+
+	// Use own copy method of the type
+	if cloned, ok, err := cloneValue(src); err != nil {
+		return err
+	} else if ok {
+		if dst.CanSet() {
+			dst.Set(cloned)
+		}
+		return nil
+	}
+
 	// Get the underlying value if src is an interface
 	if src.Kind() == reflect.Interface && !src.IsNil() {
 		srcElem := src.Elem()
 		if dst.CanSet() {
 			newDst := reflect.New(srcElem.Type()).Elem()
-			if err := deepCopy(srcElem, newDst, visited); err != nil {
+			if err := deepCopy(ctx, srcElem, newDst, visited); err != nil {
 				return err
 			}
 			dst.Set(newDst)
@@ -131,15 +375,15 @@ func deepCopy(src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
 	// Handle different kinds of values that need deep copying
 	switch src.Kind() {
 	case reflect.Pointer:
-		return copyPointer(src, dst, visited)
+		return copyPointer(ctx, src, dst, visited)
 	case reflect.Struct:
-		return copyStruct(src, dst, visited)
+		return copyStruct(ctx, src, dst, visited)
 	case reflect.Slice:
-		return copySlice(src, dst, visited)
+		return copySlice(ctx, src, dst, visited)
 	case reflect.Array:
-		return copyArray(src, dst, visited)
+		return copyArray(ctx, src, dst, visited)
 	case reflect.Map:
-		return copyMap(src, dst, visited)
+		return copyMap(ctx, src, dst, visited)
 	case reflect.Chan, reflect.Func:
 		return wrapError(ErrCopyUnsupportedType, src.Type().String())
 	default:
@@ -175,6 +419,34 @@ func deepCopyWithOptions(src, dst reflect.Value, visited map[uintptr]reflect.Val
 			dst.Set(src)
 		}
 		return nil
+	}
+
+	if dst.CanSet() && !(opts.noShare && copyPolicyOf(src.Type()) == CopyPolicyShare) && applyCopyPolicy(src, dst) {
+		return nil
+	}
+
+	return deepCopyValueWithOptions(src, dst, visited, opts, depth)
+}
+
+// deepCopyValueWithOptions copies the value recursively without the type copy policy check
+func deepCopyValueWithOptions(src, dst reflect.Value, visited map[uintptr]reflect.Value, opts CopyOptions, depth int) error {
+	if !src.IsValid() {
+		return nil
+	}
+
+	// Use own copy method of the type if no option changes the copy of its fields
+	if opts.useCloner() {
+		if cloned, ok, err := cloneValue(src); err != nil {
+			return err
+		} else if ok {
+			if dst.CanSet() {
+				dst.Set(cloned)
+			}
+			return nil
+		}
+	}
+
+	switch src.Kind() {
 	case reflect.Interface:
 		if !src.IsNil() {
 			srcElem := src.Elem()
@@ -208,7 +480,7 @@ func deepCopyWithOptions(src, dst reflect.Value, visited map[uintptr]reflect.Val
 	return nil
 }
 
-func copyPointer(src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
+func copyPointer(ctx context.Context, src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
 	if src.IsNil() {
 		return nil
 	}
@@ -232,7 +504,7 @@ func copyPointer(src, dst reflect.Value, visited map[uintptr]reflect.Value) erro
 	visited[ptr] = newPtr
 
 	// Recursively copy the pointed-to value
-	return deepCopy(src.Elem(), newPtr.Elem(), visited)
+	return deepCopy(ctx, src.Elem(), newPtr.Elem(), visited)
 }
 
 func copyPointerWithOptions(src, dst reflect.Value, visited map[uintptr]reflect.Value, opts CopyOptions, depth int) error {
@@ -260,19 +532,50 @@ func copyPointerWithOptions(src, dst reflect.Value, visited map[uintptr]reflect.
 	return deepCopyWithOptions(src.Elem(), newPtr.Elem(), visited, opts, depth+1)
 }
 
-func copyStruct(src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
+func copyStruct(ctx context.Context, src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
+	src = addressableValue(src)
 	for i := 0; i < src.NumField(); i++ {
-		if !dst.Field(i).CanSet() {
-			continue // Skip unexported fields
+		if err := contextError(ctx, i); err != nil {
+			return wrapError(err, src.Type().Field(i).Name)
 		}
-		if err := deepCopy(src.Field(i), dst.Field(i), visited); err != nil {
-			return err
+		dstField := accessibleField(dst, i)
+		if !dstField.CanSet() {
+			continue // Skip fields of not addressable struct
+		}
+		var (
+			err      error
+			srcField = accessibleField(src, i)
+		)
+		switch fieldCopyAction(src.Type().Field(i)) {
+		case CopyActionSkip:
+			dstField.Set(reflect.Zero(dstField.Type()))
+		case CopyActionShallow:
+			dstField.Set(srcField)
+		case CopyActionDeep:
+			err = deepCopyValue(ctx, srcField, dstField, visited)
+		default:
+			err = deepCopy(ctx, srcField, dstField, visited)
+		}
+		if err != nil && !shareUnexportedField(src.Type().Field(i), srcField, dstField, err) {
+			return wrapContextError(err, src.Type().Field(i).Name)
 		}
 	}
 	return nil
 }
 
+// shareUnexportedField sets the source value of the unexported field which contains
+// channels or functions and returns true, such fields are internal state of the type
+// and they can't be copied deeply
+func shareUnexportedField(ft reflect.StructField, src, dst reflect.Value, err error) bool {
+	if ft.IsExported() || !errors.Is(err, ErrCopyUnsupportedType) {
+		return false
+	}
+	dst.Set(src)
+	return true
+}
+
 func copyStructWithOptions(src, dst reflect.Value, visited map[uintptr]reflect.Value, opts CopyOptions, depth int) error {
+	src = addressableValue(src)
 	for i := 0; i < src.NumField(); i++ {
 		field := src.Type().Field(i)
 
@@ -281,18 +584,34 @@ func copyStructWithOptions(src, dst reflect.Value, visited map[uintptr]reflect.V
 			continue
 		}
 
-		if !dst.Field(i).CanSet() {
+		dstField := accessibleField(dst, i)
+		if !dstField.CanSet() {
 			continue
 		}
 
-		if err := deepCopyWithOptions(src.Field(i), dst.Field(i), visited, opts, depth+1); err != nil {
+		var (
+			err       error
+			srcField  = accessibleField(src, i)
+			fieldOpts = opts.withField(field.Name)
+		)
+		switch opts.fieldCopyAction(field) {
+		case CopyActionSkip:
+			dstField.Set(reflect.Zero(dstField.Type()))
+		case CopyActionShallow:
+			dstField.Set(srcField)
+		case CopyActionDeep:
+			err = deepCopyValueWithOptions(srcField, dstField, visited, fieldOpts, depth+1)
+		default:
+			err = deepCopyWithOptions(srcField, dstField, visited, fieldOpts, depth+1)
+		}
+		if err != nil && !shareUnexportedField(field, srcField, dstField, err) {
 			return err
 		}
 	}
 	return nil
 }
 
-func copySlice(src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
+func copySlice(ctx context.Context, src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
 	if src.IsNil() {
 		return nil
 	}
@@ -301,8 +620,11 @@ func copySlice(src, dst reflect.Value, visited map[uintptr]reflect.Value) error
 	dst.Set(newSlice)
 
 	for i := 0; i < src.Len(); i++ {
-		if err := deepCopy(src.Index(i), dst.Index(i), visited); err != nil {
-			return err
+		if err := contextError(ctx, i); err != nil {
+			return wrapError(err, sliceIndexPath(i))
+		}
+		if err := deepCopy(ctx, src.Index(i), dst.Index(i), visited); err != nil {
+			return wrapContextError(err, sliceIndexPath(i))
 		}
 	}
 	return nil
@@ -324,10 +646,13 @@ func copySliceWithOptions(src, dst reflect.Value, visited map[uintptr]reflect.Va
 	return nil
 }
 
-func copyArray(src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
+func copyArray(ctx context.Context, src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
 	for i := 0; i < src.Len(); i++ {
-		if err := deepCopy(src.Index(i), dst.Index(i), visited); err != nil {
-			return err
+		if err := contextError(ctx, i); err != nil {
+			return wrapError(err, sliceIndexPath(i))
+		}
+		if err := deepCopy(ctx, src.Index(i), dst.Index(i), visited); err != nil {
+			return wrapContextError(err, sliceIndexPath(i))
 		}
 	}
 	return nil
@@ -342,7 +667,7 @@ func copyArrayWithOptions(src, dst reflect.Value, visited map[uintptr]reflect.Va
 	return nil
 }
 
-func copyMap(src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
+func copyMap(ctx context.Context, src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
 	if src.IsNil() {
 		return nil
 	}
@@ -351,18 +676,21 @@ func copyMap(src, dst reflect.Value, visited map[uintptr]reflect.Value) error {
 	dst.Set(newMap)
 
 	iter := src.MapRange()
-	for iter.Next() {
+	for i := 0; iter.Next(); i++ {
 		srcKey := iter.Key()
 		srcValue := iter.Value()
+		if err := contextError(ctx, i); err != nil {
+			return wrapError(err, `"`+Str(srcKey.Interface())+`" map key`)
+		}
 
 		dstKey := reflect.New(srcKey.Type()).Elem()
-		if err := deepCopy(srcKey, dstKey, visited); err != nil {
-			return err
+		if err := deepCopy(ctx, srcKey, dstKey, visited); err != nil {
+			return wrapContextError(err, `"`+Str(srcKey.Interface())+`" map key`)
 		}
 
 		dstValue := reflect.New(srcValue.Type()).Elem()
-		if err := deepCopy(srcValue, dstValue, visited); err != nil {
-			return err
+		if err := deepCopy(ctx, srcValue, dstValue, visited); err != nil {
+			return wrapContextError(err, `"`+Str(srcKey.Interface())+`" map key`)
 		}
 
 		dst.SetMapIndex(dstKey, dstValue)
@@ -1,9 +1,14 @@
 package gocast
 
 import (
+	"context"
 	"errors"
+	"os"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -222,16 +227,288 @@ func TestCopyOptionsIgnoreUnexportedFields(t *testing.T) {
 
 	src := mixed{Exported: 10, unexported: 99}
 
-	t.Run("without option copies exported fields only (unexported not settable)", func(t *testing.T) {
+	t.Run("without option copies all fields", func(t *testing.T) {
 		dst, err := TryCopyWithOptions(src, CopyOptions{})
 		assert.NoError(t, err)
 		assert.Equal(t, src.Exported, dst.Exported)
+		assert.Equal(t, src.unexported, dst.unexported)
 	})
 
 	t.Run("IgnoreUnexportedFields copies exported fields", func(t *testing.T) {
 		dst, err := TryCopyWithOptions(src, CopyOptions{IgnoreUnexportedFields: true})
 		assert.NoError(t, err)
 		assert.Equal(t, src.Exported, dst.Exported)
+		assert.Equal(t, 0, dst.unexported)
+	})
+}
+
+type testCopyCloner struct {
+	Items  []int
+	cloned bool
+}
+
+func (c *testCopyCloner) Clone() *testCopyCloner {
+	return &testCopyCloner{Items: append([]int(nil), c.Items...), cloned: true}
+}
+
+type testCopyDeepCopier struct{ Name string }
+
+func (c testCopyDeepCopier) DeepCopy() testCopyDeepCopier {
+	return testCopyDeepCopier{Name: c.Name + " copy"}
+}
+
+type testCopyErrCloner struct{ Name string }
+
+func (c testCopyErrCloner) DeepCopy() (testCopyErrCloner, error) {
+	if c.Name == "" {
+		return c, errors.New("empty name")
+	}
+	return testCopyErrCloner{Name: c.Name + " copy"}, nil
+}
+
+func TestCopyUnexportedFields(t *testing.T) {
+	type inner struct {
+		values []int
+		name   string
+	}
+	type outer struct {
+		Public  string
+		private *inner
+		items   map[string][]int
+		created time.Time
+	}
+
+	src := outer{
+		Public:  "pub",
+		private: &inner{values: []int{1, 2}, name: "in"},
+		items:   map[string][]int{"a": {1}},
+		created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local),
+	}
+	dst, err := TryCopy(src)
+	assert.NoError(t, err)
+	assert.Equal(t, src, dst)
+	assert.True(t, src.created.Equal(dst.created))
+	assert.Same(t, src.created.Location(), dst.created.Location())
+
+	// Verify independence
+	dst.private.values[0] = 100
+	dst.items["a"][0] = 100
+	assert.Equal(t, 1, src.private.values[0])
+	assert.Equal(t, 1, src.items["a"][0])
+
+	t.Run("channels and functions", func(t *testing.T) {
+		type handlers struct {
+			OnClose func()
+		}
+		type service struct {
+			Name   string
+			events chan int
+			call   func() int
+			hooks  handlers
+		}
+		src := service{
+			Name:   "svc",
+			events: make(chan int),
+			call:   func() int { return 1 },
+			hooks:  handlers{OnClose: func() {}},
+		}
+		dst, err := TryCopy(src)
+		assert.NoError(t, err)
+		assert.Equal(t, "svc", dst.Name)
+		assert.Equal(t, src.events, dst.events)
+		assert.Equal(t, 1, dst.call())
+		assert.NotNil(t, dst.hooks.OnClose)
+
+		dst, err = TryCopyWithOptions(src, CopyOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, src.events, dst.events)
+
+		// Exported channels still require the copy policy
+		_, err = TryCopy(struct{ Events chan int }{})
+		assert.ErrorIs(t, err, ErrCopyUnsupportedType)
+	})
+}
+
+func TestCopyCloner(t *testing.T) {
+	t.Run("pointer", func(t *testing.T) {
+		type holder struct{ C *testCopyCloner }
+		src := holder{C: &testCopyCloner{Items: []int{1}}}
+		dst, err := TryCopy(src)
+		assert.NoError(t, err)
+		assert.True(t, dst.C.cloned)
+		assert.Equal(t, []int{1}, dst.C.Items)
+		assert.NotSame(t, src.C, dst.C)
+	})
+
+	t.Run("value", func(t *testing.T) {
+		dst, err := TryCopy([]testCopyDeepCopier{{Name: "a"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []testCopyDeepCopier{{Name: "a copy"}}, dst)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dst, err := TryCopy(map[string]testCopyErrCloner{"a": {Name: "a"}})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]testCopyErrCloner{"a": {Name: "a copy"}}, dst)
+
+		_, err = TryCopy([]testCopyErrCloner{{Name: "a"}, {}})
+		assert.EqualError(t, err, "empty name")
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var src *testCopyCloner
+		dst, err := TryCopy(src)
+		assert.NoError(t, err)
+		assert.Nil(t, dst)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		type holder struct{ C *testCopyCloner }
+		src := holder{C: &testCopyCloner{Items: []int{1}}}
+
+		dst, err := TryCopyWithOptions(src, CopyOptions{})
+		assert.NoError(t, err)
+		assert.True(t, dst.C.cloned)
+
+		// The options are applied to the fields instead of the own copy method
+		dst, err = TryCopyWithOptions(src, CopyOptions{
+			FieldFilter: func(path []string, f reflect.StructField) CopyAction {
+				if f.Name == "Items" {
+					return CopyActionSkip
+				}
+				return CopyActionDefault
+			},
+		})
+		assert.NoError(t, err)
+		assert.False(t, dst.C.cloned)
+		assert.Nil(t, dst.C.Items)
+
+		dst, err = TryCopyWithOptions(src, CopyOptions{IgnoreUnexportedFields: true})
+		assert.NoError(t, err)
+		assert.False(t, dst.C.cloned)
+		assert.Equal(t, []int{1}, dst.C.Items)
+
+		dst, err = TryCopyWithOptions(src, CopyOptions{MaxDepth: 2})
+		assert.NoError(t, err)
+		assert.False(t, dst.C.cloned)
+		assert.Nil(t, dst.C.Items)
+	})
+}
+
+func TestCopyPolicy(t *testing.T) {
+	type testPolicyChan chan int
+	type testPolicyFunc func() int
+	RegisterCopyPolicy[testPolicyChan](CopyPolicyShare)
+	RegisterCopyPolicy[testPolicyFunc](CopyPolicyZero)
+
+	type service struct {
+		mu      sync.Mutex
+		lock    *sync.RWMutex
+		File    *os.File
+		Events  testPolicyChan
+		Handler testPolicyFunc
+		Count   int
+	}
+
+	src := &service{
+		lock:    &sync.RWMutex{},
+		File:    os.Stdout,
+		Events:  make(testPolicyChan),
+		Handler: func() int { return 1 },
+		Count:   2,
+	}
+	src.mu.Lock()
+	src.lock.Lock()
+	defer src.mu.Unlock()
+	defer src.lock.Unlock()
+
+	dst, err := TryCopy(src)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, dst.mu.TryLock(), "mutex must be copied unlocked")
+	if assert.NotNil(t, dst.lock) {
+		assert.NotSame(t, src.lock, dst.lock)
+		assert.True(t, dst.lock.TryLock(), "rwmutex must be copied unlocked")
+	}
+	assert.Same(t, os.Stdout, dst.File)
+	assert.Equal(t, src.Events, dst.Events)
+	assert.Nil(t, dst.Handler)
+	assert.Equal(t, 2, dst.Count)
+
+	_, err = TryCopy(make(chan int))
+	assert.ErrorIs(t, err, ErrCopyUnsupportedType)
+}
+
+type testCopyShared struct{ Name string }
+
+func TestCopyFieldTags(t *testing.T) {
+	RegisterCopyPolicy[*testCopyShared](CopyPolicyShare)
+
+	type config struct {
+		Cache  map[string]int `copy:"-"`
+		Labels []string       `copy:"shallow"`
+		Owner  *testCopyShared
+		Copied *testCopyShared `copy:"deep"`
+		Tags   []string
+	}
+	type item struct {
+		Config config
+		Token  string
+	}
+
+	src := &item{
+		Config: config{
+			Cache:  map[string]int{"a": 1},
+			Labels: []string{"x"},
+			Owner:  &testCopyShared{Name: "owner"},
+			Copied: &testCopyShared{Name: "copied"},
+			Tags:   []string{"t"},
+		},
+		Token: "secret",
+	}
+
+	t.Run("tags", func(t *testing.T) {
+		dst, err := TryCopy(src)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Nil(t, dst.Config.Cache)
+		assert.Same(t, &src.Config.Labels[0], &dst.Config.Labels[0])
+		assert.Same(t, src.Config.Owner, dst.Config.Owner)
+		assert.NotSame(t, src.Config.Copied, dst.Config.Copied)
+		assert.Equal(t, src.Config.Copied, dst.Config.Copied)
+		assert.NotSame(t, &src.Config.Tags[0], &dst.Config.Tags[0])
+		assert.Equal(t, "secret", dst.Token)
+	})
+
+	t.Run("field_filter", func(t *testing.T) {
+		var paths []string
+		dst, err := TryCopyWithOptions(src, CopyOptions{
+			FieldFilter: func(path []string, f reflect.StructField) CopyAction {
+				paths = append(paths, strings.Join(append(path, f.Name), "."))
+				switch {
+				case f.Name == "Token":
+					return CopyActionSkip
+				case f.Name == "Tags" && len(path) == 1 && path[0] == "Config":
+					return CopyActionShallow
+				case f.Name == "Owner":
+					return CopyActionDeep
+				}
+				return CopyActionDefault
+			},
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Empty(t, dst.Token)
+		assert.Nil(t, dst.Config.Cache, "tag must be used for the default action")
+		assert.Same(t, &src.Config.Tags[0], &dst.Config.Tags[0])
+		assert.NotSame(t, src.Config.Owner, dst.Config.Owner)
+		assert.Equal(t, "owner", dst.Config.Owner.Name)
+		assert.Contains(t, paths, "Config.Labels")
+		assert.Contains(t, paths, "Config.Owner.Name")
+		assert.Contains(t, paths, "Token")
 	})
 }
 
@@ -246,6 +523,73 @@ func TestCopyArray(t *testing.T) {
 	assert.Equal(t, 10, src[0])
 }
 
+func TestCopyInterface(t *testing.T) {
+	var src any = map[string]any{"list": []any{1, "2"}}
+	dst, err := TryCopy(src)
+	assert.NoError(t, err)
+	assert.Equal(t, src, dst)
+
+	// Verify independence
+	dst.(map[string]any)["list"].([]any)[0] = 99
+	assert.Equal(t, 1, src.(map[string]any)["list"].([]any)[0])
+}
+
+// testCopyCancelItem cancels the copy on the value 100
+type testCopyCancelItem struct {
+	Value  int
+	cancel context.CancelFunc
+}
+
+func (it testCopyCancelItem) Clone() testCopyCancelItem {
+	if it.Value == 100 {
+		it.cancel()
+	}
+	return it
+}
+
+// testCopyCountdownContext is canceled after n checks of the error
+type testCopyCountdownContext struct {
+	context.Context
+	n int
+}
+
+func (ctx *testCopyCountdownContext) Err() error {
+	if ctx.n--; ctx.n < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestCopyContext(t *testing.T) {
+	type listType struct {
+		Items []testCopyCancelItem
+		Index map[string]int
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	list := listType{Items: make([]testCopyCancelItem, 1000), Index: map[string]int{"a": 1}}
+	for i := range list.Items {
+		list.Items[i] = testCopyCancelItem{Value: i, cancel: cancel}
+	}
+	_, err := TryCopyContext(ctx, list)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.EqualError(t, err, "Items: [128]: context canceled")
+
+	_, err = TryCopyContext(ctx, map[string]int{"a": 1})
+	assert.EqualError(t, err, `"a" map key: context canceled`)
+
+	// The key is canceled after the check of the map loop
+	type key struct{ Name string }
+	_, err = TryCopyContext(&testCopyCountdownContext{Context: context.Background(), n: 1}, map[key]int{{Name: "k"}: 1})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.EqualError(t, err, `"{k}" map key: Name: context canceled`)
+
+	res, err := TryCopyContext(context.Background(), list)
+	assert.NoError(t, err)
+	assert.Equal(t, list.Index, res.Index)
+}
+
 func TestCopyArrayWithOptions(t *testing.T) {
 	src := [3]string{"a", "b", "c"}
 	dst, err := TryCopyWithOptions(src, CopyOptions{})
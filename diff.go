@@ -0,0 +1,288 @@
+package gocast
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeType defines the kind of the change
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+// Change describes the difference of the value by the path
+type Change struct {
+	Type ChangeType `json:"type"`
+	Path []string   `json:"path"`
+	Old  any        `json:"old,omitempty"`
+	New  any        `json:"new,omitempty"`
+}
+
+// PathString returns the path joined by dots
+func (c Change) PathString() string {
+	return strings.Join(c.Path, ".")
+}
+
+// DiffOption defines the option of the Diff function
+type DiffOption func(opts *diffOptions)
+
+type diffOptions struct {
+	tags []string
+	key  string
+}
+
+// WithDiffTags sets the struct tags used to name fields in the path, "json" by default
+func WithDiffTags(tags ...string) DiffOption {
+	return func(opts *diffOptions) {
+		opts.tags = tags
+	}
+}
+
+// WithDiffKey sets the name of the field which identifies the slice items, "id" by default.
+// Empty name compares slices by index, the slices with missing or duplicate keys are compared by index too.
+func WithDiffKey(name string) DiffOption {
+	return func(opts *diffOptions) {
+		opts.key = name
+	}
+}
+
+type differ struct {
+	opts    diffOptions
+	visited map[[2]uintptr]bool
+	changes []Change
+}
+
+// Diff returns the list of changes between two values.
+// Structs are compared field by field with names from the json tag,
+// maps by keys, slices of structs or maps with the "id" field by the id
+// and other slices by index. Pointers and interfaces are compared by the values.
+//
+//	for _, change := range gocast.Diff(oldUser, newUser) {
+//	    log.Printf("%s %s: %v -> %v", change.Type, change.PathString(), change.Old, change.New)
+//	}
+func Diff(a, b any, opts ...DiffOption) []Change {
+	df := differ{
+		opts:    diffOptions{tags: []string{"json"}, key: "id"},
+		visited: map[[2]uintptr]bool{},
+	}
+	for _, opt := range opts {
+		opt(&df.opts)
+	}
+	df.diff(nil, reflect.ValueOf(a), reflect.ValueOf(b))
+	return df.changes
+}
+
+func (df *differ) add(tp ChangeType, path []string, a, b reflect.Value) {
+	change := Change{Type: tp, Path: path}
+	if a.IsValid() && a.CanInterface() {
+		change.Old = a.Interface()
+	}
+	if b.IsValid() && b.CanInterface() {
+		change.New = b.Interface()
+	}
+	df.changes = append(df.changes, change)
+}
+
+// derefValue returns the target value of pointers and interfaces, nil values are invalid
+func derefValue(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func (df *differ) diff(path []string, a, b reflect.Value) {
+	// Prevent endless recursion for the circular references
+	if a.IsValid() && b.IsValid() && a.Kind() == reflect.Pointer && b.Kind() == reflect.Pointer && !a.IsNil() && !b.IsNil() {
+		key := [2]uintptr{a.Pointer(), b.Pointer()}
+		if df.visited[key] {
+			return
+		}
+		df.visited[key] = true
+	}
+
+	a, b = derefValue(a), derefValue(b)
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return
+	case !a.IsValid():
+		df.add(ChangeAdded, path, a, b)
+		return
+	case !b.IsValid():
+		df.add(ChangeRemoved, path, a, b)
+		return
+	case a.Type() != b.Type():
+		df.add(ChangeModified, path, a, b)
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Struct:
+		if isMergeableStruct(a.Type()) && !hasEqualMethod(a.Type()) {
+			df.diffStruct(path, a, b)
+			return
+		}
+	case reflect.Map:
+		df.diffMap(path, a, b)
+		return
+	case reflect.Slice, reflect.Array:
+		if a.Type() != bytesType {
+			if df.isKeyed(a, b) {
+				df.diffKeyedList(path, a, b)
+			} else {
+				df.diffList(path, a, b)
+			}
+			return
+		}
+	}
+	if !valuesEqual(a, b) {
+		df.add(ChangeModified, path, a, b)
+	}
+}
+
+func (df *differ) diffStruct(path []string, a, b reflect.Value) {
+	for _, ft := range ReflectStructFields(a.Type()) {
+		if !ft.IsExported() || (len(df.opts.tags) > 0 && fieldTag(ft, df.opts.tags[0]) == "") {
+			continue
+		}
+		name, _ := fieldNameFromTags(ft, df.opts.tags...)
+		df.diff(appendPath(path, name), a.FieldByName(ft.Name), b.FieldByName(ft.Name))
+	}
+}
+
+func (df *differ) diffMap(path []string, a, b reflect.Value) {
+	keys := map[string]reflect.Value{}
+	for _, key := range a.MapKeys() {
+		keys[Str(key.Interface())] = key
+	}
+	for _, key := range b.MapKeys() {
+		keys[Str(key.Interface())] = key
+	}
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		key := keys[name]
+		df.diff(appendPath(path, name), a.MapIndex(key), b.MapIndex(key))
+	}
+}
+
+func (df *differ) diffList(path []string, a, b reflect.Value) {
+	size := a.Len()
+	if b.Len() > size {
+		size = b.Len()
+	}
+	for i := 0; i < size; i++ {
+		var aItem, bItem reflect.Value
+		if i < a.Len() {
+			aItem = a.Index(i)
+		}
+		if i < b.Len() {
+			bItem = b.Index(i)
+		}
+		if !aItem.IsValid() {
+			df.add(ChangeAdded, appendPath(path, Str(i)), aItem, bItem)
+		} else if !bItem.IsValid() {
+			df.add(ChangeRemoved, appendPath(path, Str(i)), aItem, bItem)
+		} else {
+			df.diff(appendPath(path, Str(i)), aItem, bItem)
+		}
+	}
+}
+
+func (df *differ) diffKeyedList(path []string, a, b reflect.Value) {
+	bItems := make(map[string]reflect.Value, b.Len())
+	for i := 0; i < b.Len(); i++ {
+		bItems[df.itemKey(b.Index(i))] = b.Index(i)
+	}
+	aKeys := make(map[string]bool, a.Len())
+	for i := 0; i < a.Len(); i++ {
+		key := df.itemKey(a.Index(i))
+		aKeys[key] = true
+		if bItem, ok := bItems[key]; ok {
+			df.diff(appendPath(path, key), a.Index(i), bItem)
+		} else {
+			df.add(ChangeRemoved, appendPath(path, key), a.Index(i), reflect.Value{})
+		}
+	}
+	for i := 0; i < b.Len(); i++ {
+		if key := df.itemKey(b.Index(i)); !aKeys[key] {
+			df.add(ChangeAdded, appendPath(path, key), reflect.Value{}, b.Index(i))
+		}
+	}
+}
+
+// isKeyed returns true if all items of both lists have the unique key field,
+// the empty list is compared by the keys of the other list
+func (df *differ) isKeyed(a, b reflect.Value) bool {
+	if df.opts.key == "" || a.Len()+b.Len() == 0 {
+		return false
+	}
+	for _, list := range []reflect.Value{a, b} {
+		keys := make(map[string]bool, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			key := df.itemKeyValue(list.Index(i))
+			if !key.IsValid() || keys[Str(key.Interface())] {
+				return false
+			}
+			keys[Str(key.Interface())] = true
+		}
+	}
+	return true
+}
+
+func (df *differ) itemKey(item reflect.Value) string {
+	return Str(df.itemKeyValue(item).Interface())
+}
+
+// itemKeyValue returns the value of the key field of the struct or map item
+func (df *differ) itemKeyValue(item reflect.Value) reflect.Value {
+	item = derefValue(item)
+	switch {
+	case !item.IsValid():
+	case item.Kind() == reflect.Struct:
+		for _, ft := range ReflectStructFields(item.Type()) {
+			if !ft.IsExported() {
+				continue
+			}
+			if name, _ := fieldNameFromTags(ft, df.opts.tags...); strings.EqualFold(name, df.opts.key) {
+				return derefValue(item.FieldByName(ft.Name))
+			}
+		}
+	case item.Kind() == reflect.Map && item.Type().Key().Kind() == reflect.String:
+		return derefValue(item.MapIndex(reflect.ValueOf(df.opts.key).Convert(item.Type().Key())))
+	}
+	return reflect.Value{}
+}
+
+func appendPath(path []string, name string) []string {
+	return append(path[:len(path):len(path)], name)
+}
+
+// hasEqualMethod returns true if the type has `Equal(T) bool` method like time.Time
+func hasEqualMethod(t reflect.Type) bool {
+	m, ok := t.MethodByName("Equal")
+	return ok && m.Type.NumIn() == 2 && m.Type.In(1) == t &&
+		m.Type.NumOut() == 1 && m.Type.Out(0).Kind() == reflect.Bool
+}
+
+// valuesEqual compares two values of the same type
+func valuesEqual(a, b reflect.Value) bool {
+	if !a.CanInterface() || !b.CanInterface() {
+		return true
+	}
+	if hasEqualMethod(a.Type()) {
+		return a.MethodByName("Equal").Call([]reflect.Value{b})[0].Bool()
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
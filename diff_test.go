@@ -0,0 +1,126 @@
+package gocast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDiffItem struct {
+	ID    int     `json:"id"`
+	Title string  `json:"title"`
+	Price float64 `json:"price"`
+}
+
+type testDiffOrder struct {
+	ID       int               `json:"id"`
+	Customer *testDiffCustomer `json:"customer"`
+	Items    []testDiffItem    `json:"items"`
+	Tags     []string          `json:"tags"`
+	Meta     map[string]any    `json:"meta"`
+	Updated  time.Time         `json:"updated"`
+	Internal string            `json:"-"`
+	Parent   *testDiffOrder    `json:"parent,omitempty"`
+}
+
+type testDiffCustomer struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func TestDiff(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	a := &testDiffOrder{
+		ID:       1,
+		Customer: &testDiffCustomer{Name: "Bob", Email: "bob@example.com"},
+		Items:    []testDiffItem{{ID: 1, Title: "Book", Price: 10}, {ID: 2, Title: "Pen", Price: 1}},
+		Tags:     []string{"new"},
+		Meta:     map[string]any{"source": "web", "coupon": "X"},
+		Updated:  now,
+		Internal: "a",
+	}
+	b := &testDiffOrder{
+		ID:       1,
+		Customer: &testDiffCustomer{Name: "Bob", Email: "bob@example.org"},
+		Items:    []testDiffItem{{ID: 2, Title: "Pen", Price: 2}, {ID: 3, Title: "Cup", Price: 5}},
+		Tags:     []string{"new", "paid"},
+		Meta:     map[string]any{"source": "web", "ip": "127.0.0.1"},
+		Updated:  now.In(time.FixedZone("X", 3600)),
+		Internal: "b",
+	}
+	a.Parent, b.Parent = a, b
+
+	t.Run("changes", func(t *testing.T) {
+		changes := Diff(a, b)
+		assert.Equal(t, []Change{
+			{Type: ChangeModified, Path: []string{"customer", "email"}, Old: "bob@example.com", New: "bob@example.org"},
+			{Type: ChangeRemoved, Path: []string{"items", "1"}, Old: testDiffItem{ID: 1, Title: "Book", Price: 10}},
+			{Type: ChangeModified, Path: []string{"items", "2", "price"}, Old: 1.0, New: 2.0},
+			{Type: ChangeAdded, Path: []string{"items", "3"}, New: testDiffItem{ID: 3, Title: "Cup", Price: 5}},
+			{Type: ChangeAdded, Path: []string{"tags", "1"}, New: "paid"},
+			{Type: ChangeRemoved, Path: []string{"meta", "coupon"}, Old: "X"},
+			{Type: ChangeAdded, Path: []string{"meta", "ip"}, New: "127.0.0.1"},
+		}, changes)
+		assert.Equal(t, "customer.email", changes[0].PathString())
+	})
+
+	t.Run("by_index", func(t *testing.T) {
+		changes := Diff(a.Items, b.Items, WithDiffKey(""))
+		assert.Equal(t, []Change{
+			{Type: ChangeModified, Path: []string{"0", "id"}, Old: 1, New: 2},
+			{Type: ChangeModified, Path: []string{"0", "title"}, Old: "Book", New: "Pen"},
+			{Type: ChangeModified, Path: []string{"0", "price"}, Old: 10.0, New: 2.0},
+			{Type: ChangeModified, Path: []string{"1", "id"}, Old: 2, New: 3},
+			{Type: ChangeModified, Path: []string{"1", "title"}, Old: "Pen", New: "Cup"},
+			{Type: ChangeModified, Path: []string{"1", "price"}, Old: 1.0, New: 5.0},
+		}, changes)
+	})
+
+	t.Run("empty_list", func(t *testing.T) {
+		changes := Diff([]testDiffItem{}, b.Items)
+		assert.Equal(t, []Change{
+			{Type: ChangeAdded, Path: []string{"2"}, New: testDiffItem{ID: 2, Title: "Pen", Price: 2}},
+			{Type: ChangeAdded, Path: []string{"3"}, New: testDiffItem{ID: 3, Title: "Cup", Price: 5}},
+		}, changes)
+		changes = Diff(a.Items, []testDiffItem{})
+		assert.Equal(t, []Change{
+			{Type: ChangeRemoved, Path: []string{"1"}, Old: testDiffItem{ID: 1, Title: "Book", Price: 10}},
+			{Type: ChangeRemoved, Path: []string{"2"}, Old: testDiffItem{ID: 2, Title: "Pen", Price: 1}},
+		}, changes)
+	})
+
+	t.Run("duplicate_keys", func(t *testing.T) {
+		// The lists with duplicate keys are compared by index
+		a := []testDiffItem{{ID: 1, Title: "Book"}, {ID: 1, Title: "Pen"}}
+		b := []testDiffItem{{ID: 1, Title: "Book"}, {ID: 1, Title: "Cup"}}
+		assert.Equal(t, []Change{
+			{Type: ChangeModified, Path: []string{"1", "title"}, Old: "Pen", New: "Cup"},
+		}, Diff(a, b))
+		assert.Equal(t, []Change{
+			{Type: ChangeAdded, Path: []string{"1"}, New: testDiffItem{ID: 1, Title: "Cup"}},
+		}, Diff(a[:1], []testDiffItem{b[0], b[1]}))
+	})
+
+	t.Run("maps", func(t *testing.T) {
+		a := map[string]any{"list": []any{map[string]any{"id": "x", "v": 1}}, "n": nil}
+		b := map[string]any{"list": []any{map[string]any{"id": "x", "v": 2}}, "n": 1}
+		assert.Equal(t, []Change{
+			{Type: ChangeModified, Path: []string{"list", "x", "v"}, Old: 1, New: 2},
+			{Type: ChangeAdded, Path: []string{"n"}, New: 1},
+		}, Diff(a, b))
+	})
+
+	t.Run("field_names", func(t *testing.T) {
+		changes := Diff(testDiffCustomer{Name: "A"}, testDiffCustomer{Name: "B"}, WithDiffTags("-"))
+		assert.Equal(t, []Change{{Type: ChangeModified, Path: []string{"Name"}, Old: "A", New: "B"}}, changes)
+	})
+
+	t.Run("equal", func(t *testing.T) {
+		assert.Empty(t, Diff(a, a))
+		assert.Empty(t, Diff(nil, nil))
+		assert.Empty(t, Diff([]int(nil), []int{}))
+		assert.Equal(t, []Change{{Type: ChangeModified, Old: 1, New: "1"}}, Diff(1, "1"))
+		assert.Equal(t, []Change{{Type: ChangeRemoved, Old: testDiffCustomer{}}}, Diff(&testDiffCustomer{}, nil))
+	})
+}
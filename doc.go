@@ -15,30 +15,66 @@
 //   - [Number] / [TryNumber] — fast conversion to any numeric type.
 //   - [Str] / [TryStr] — convert any value to string.
 //   - [Bool] — convert any value to bool.
+//   - [TrySlice] / [TryAnySlice] — convert slices element by element,
+//     [TryAnySliceParallel] splits large inputs between goroutines.
+//   - [CastSeq] / [TryCastSeq2] — convert iterators lazily or into maps (Go 1.23+),
+//     slices can also be read from channels and iterators by [TryAnySlice].
+//   - [ContextWithSliceCoercion] — convert delimited strings, JSON arrays and
+//     scalars into slices.
+//   - [ContextWithArrayLength] — truncate, zero-pad or reject the sources which
+//     don't fit into fixed-size arrays.
 //
 // By convention:
 //   - Functions prefixed with Try return (T, error).
 //   - Functions without the prefix return T (zero value on error).
 //   - Functions suffixed with Context accept a [context.Context] for custom
-//     [CastSetter] hooks.
+//     [CastSetter] hooks and stop the conversion when the context is canceled.
 //
 // # Deep Copy
 //
 //   - [TryCopy] / [Copy] — deep copy any value; circular references are handled
 //     automatically via a visited-pointer map.
+//   - [TryCopyContext] — deep copy which stops when the context is canceled.
 //   - [TryCopyWithOptions] — deep copy with [CopyOptions] (max depth,
 //     unexported-field skipping, circular-reference ignoring).
+//   - [TryCopyInto] — deep copy into an existing value.
+//   - [TryMerge] / [Merge] — overlay non-empty source values onto the
+//     destination with [MergeOptions] (zero-only, append slices, union maps).
+//   - [TryTransform] / [Transform] — convert a value into another type (DTO ↔
+//     domain model) with a deep copy, the result never shares memory with the source.
+//   - [TryRedact] / [Redact] — deep copy with `secret:"true"` fields and map
+//     items with keys like password or token masked for logging.
 //   - [CopySlice] / [CopyMap] — type-safe helpers for slices and maps.
+//   - [RegisterCopyPolicy] — share or zero values of the specific type instead
+//     of copying them; types may also provide their own Clone or DeepCopy method.
 //
 // # Struct and Map Mapping
 //
 //   - [TryCopyStruct] / [Struct] — populate a struct from a map or another struct
 //     using struct-tag–driven field name resolution (json, field, sql, …).
+//   - [NewMapping] — reusable struct-to-struct mapping with computed fields,
+//     renames and ignored fields on top of the name-based copying.
+//   - [MapCopier] / [MapExporter] — converters generated by cmd/gocastgen,
+//     used instead of the reflection when the tags of the call match.
 //   - [ToMap] / [Map] / [TryMap] — convert a struct or map into a map type.
-//   - [StructWalk] — recursively visit all fields of a struct.
+//   - [ApplyPatch] / [ApplyJSONPatch] — apply RFC 7396 JSON Merge Patch or
+//     RFC 6902 JSON Patch to structs, maps and slices.
+//   - [StructWalk] — recursively visit all fields of a struct, optionally descending
+//     into slices and maps with [WalkWithSlices] and [WalkWithMaps].
+//   - [Validate] — check struct fields by the `validate` tag rules, see
+//     [RegisterValidationRule] for custom rules.
+//   - [Sanitize] — normalize struct fields by the `mod` tag modifiers (trim,
+//     lower, default=…), see [RegisterModifier] for custom modifiers.
 //   - [SetStructFieldValue] / [StructFieldValue] — get or set individual struct
 //     fields by name using reflection.
 //
+// # Comparing Values
+//
+//   - [Diff] — path-addressed list of added, removed and modified values
+//     between two versions of a struct, map or slice.
+//   - [Equal] / [EqualCast] — deep equality, optionally with conversion of
+//     values of different types ("1" equals 1, structs equal maps by tag names).
+//
 // # Custom Types
 //
 // Types can participate in the conversion pipeline by implementing [CastSetter]:
@@ -50,6 +86,16 @@
 //	    return nil
 //	}
 //
+// The reverse direction is covered by [CastGetter], which lets a source value
+// decide how it is exported into strings, numbers, maps or other structs:
+//
+//	func (m Money) CastGet(ctx context.Context, t reflect.Type) (any, error) {
+//	    if t.Kind() == reflect.String {
+//	        return fmt.Sprintf("%.2f", float64(m)/1_000_000), nil
+//	    }
+//	    return nil, gocast.ErrUnsupportedType // use the default conversion
+//	}
+//
 // # Deprecated APIs
 //
 // The following identifiers are deprecated and will be removed in v3:
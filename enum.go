@@ -0,0 +1,178 @@
+package gocast
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// enumInfo contains the names of the registered enum values
+type enumInfo struct {
+	byName map[string]reflect.Value
+	names  map[any]string
+}
+
+var enumRegistry sync.Map // map[reflect.Type]*enumInfo
+
+// RegisterEnum registers the names of the enum type values.
+// Names are matched case-insensitively during conversion into the enum type,
+// numbers are accepted only if they match one of the registered values,
+// other inputs return ErrInvalidEnumValue.
+// The conversion of the enum value into string returns its name,
+// if one value has several names (aliases) the smallest one is used.
+//
+//	gocast.RegisterEnum(map[string]Status{"draft": StatusDraft, "active": StatusActive})
+//	gocast.Cast[Status]("Active") // StatusActive
+//	gocast.Str(StatusActive)      // "active"
+func RegisterEnum[T comparable](values map[string]T) {
+	info := &enumInfo{
+		byName: make(map[string]reflect.Value, len(values)),
+		names:  make(map[any]string, len(values)),
+	}
+	for name, val := range values {
+		info.byName[strings.ToLower(name)] = reflect.ValueOf(val)
+		if prev, ok := info.names[val]; !ok || name < prev {
+			info.names[val] = name
+		}
+	}
+	enumRegistry.Store(reflect.TypeOf((*T)(nil)).Elem(), info)
+}
+
+// enumOf returns the registered enum info of the type or nil
+func enumOf(t reflect.Type) *enumInfo {
+	if t == nil || t.PkgPath() == "" {
+		return nil
+	}
+	if info, ok := enumRegistry.Load(t); ok {
+		return info.(*enumInfo)
+	}
+	return nil
+}
+
+// enumName returns the name of the registered enum value
+func enumName(v any) (string, bool) {
+	if info := enumOf(reflect.TypeOf(v)); info != nil {
+		name, ok := info.names[v]
+		return name, ok
+	}
+	return "", false
+}
+
+// parse converts the name or the number into the enum value of the type t
+func (info *enumInfo) parse(v reflect.Value, t reflect.Type) (any, error) {
+	switch v.Kind() {
+	case reflect.String:
+		if val, ok := info.byName[strings.ToLower(strings.TrimSpace(v.String()))]; ok {
+			return val.Interface(), nil
+		}
+		if t.Kind() == reflect.String {
+			if val := v.Convert(t).Interface(); info.names[val] != "" {
+				return val, nil
+			}
+		}
+		if !IsNumericStr(v.String()) {
+			return nil, wrapError(ErrInvalidEnumValue, v.String())
+		}
+	case reflect.Slice:
+		if v.Type() == bytesType {
+			return info.parse(reflect.ValueOf(string(v.Bytes())), t)
+		}
+		return nil, wrapError(ErrInvalidEnumValue, v.Type().String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+	default:
+		return nil, wrapError(ErrInvalidEnumValue, v.Type().String())
+	}
+	val, err := reflectNumberToType(v, t)
+	if err == nil && !isFloatKind(t.Kind()) && !isIntegralNumber(v) {
+		err = ErrInvalidParams
+	}
+	if err != nil {
+		return nil, wrapError(ErrInvalidEnumValue, Str(v.Interface()))
+	}
+	if _, ok := info.names[val]; !ok {
+		return nil, wrapError(ErrInvalidEnumValue, Str(v.Interface()))
+	}
+	return val, nil
+}
+
+// reflectNumberToType converts numeric or string value into the value of numeric type t
+func reflectNumberToType(v reflect.Value, t reflect.Type) (any, error) {
+	// Custom types based on the numeric types are converted by the kind
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v = reflect.ValueOf(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v = reflect.ValueOf(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		v = reflect.ValueOf(v.Float())
+	case reflect.String:
+		v = reflect.ValueOf(v.String())
+	}
+	newVal := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := TryNumber[int64](v.Interface())
+		if err != nil {
+			return nil, err
+		}
+		newVal.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := TryNumber[uint64](v.Interface())
+		if err != nil {
+			return nil, err
+		}
+		newVal.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := TryNumber[float64](v.Interface())
+		if err != nil {
+			return nil, err
+		}
+		newVal.SetFloat(n)
+	default:
+		return nil, wrapError(ErrUnsupportedNumericType, t.String())
+	}
+	return newVal.Interface(), nil
+}
+
+// isIntegralNumber returns true if the numeric or string value has no fractional part
+func isIntegralNumber(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == math.Trunc(v.Float())
+	case reflect.String:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v.String()), 64)
+		return err != nil || f == math.Trunc(f)
+	}
+	return true
+}
+
+// enumTagValue converts the value by the list of names from the `enum:"draft,active,closed"` tag.
+// Numeric fields receive the index of the name, string fields receive the name itself.
+// The nil value resets the field to zero like for the fields without the tag.
+func enumTagValue(tag string, t reflect.Type, v any) (any, error) {
+	if IsNil(v) {
+		return reflect.Zero(t).Interface(), nil
+	}
+	names := strings.Split(tag, ",")
+	val := reflectTarget(reflect.ValueOf(v))
+	if val.Kind() == reflect.String || val.Type() == bytesType {
+		s := strings.TrimSpace(Str(val.Interface()))
+		for i, name := range names {
+			if strings.EqualFold(strings.TrimSpace(name), s) {
+				return IfThen[any](t.Kind() == reflect.String, strings.TrimSpace(name), i), nil
+			}
+		}
+		if !IsNumericStr(s) {
+			return nil, wrapError(ErrInvalidEnumValue, s)
+		}
+	}
+	idx, err := TryNumber[int](val.Interface())
+	if err != nil || idx < 0 || idx >= len(names) || Str(idx) != Str(val.Interface()) {
+		return nil, wrapError(ErrInvalidEnumValue, Str(val.Interface()))
+	}
+	return IfThen[any](t.Kind() == reflect.String, strings.TrimSpace(names[idx]), idx), nil
+}
@@ -0,0 +1,124 @@
+package gocast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEnumStatus int
+
+const (
+	testEnumStatusDraft testEnumStatus = iota
+	testEnumStatusActive
+	testEnumStatusClosed
+)
+
+type testEnumColor string
+
+func init() {
+	RegisterEnum(map[string]testEnumStatus{
+		"draft":  testEnumStatusDraft,
+		"active": testEnumStatusActive,
+		"closed": testEnumStatusClosed,
+		"done":   testEnumStatusClosed,
+	})
+	RegisterEnum(map[string]testEnumColor{"red": "R", "green": "G"})
+}
+
+func TestEnum(t *testing.T) {
+	t.Run("from_name", func(t *testing.T) {
+		assert.Equal(t, testEnumStatusActive, Cast[testEnumStatus]("active"))
+		assert.Equal(t, testEnumStatusActive, Cast[testEnumStatus]("ACTIVE"))
+		assert.Equal(t, testEnumStatusClosed, Cast[testEnumStatus]("Done"))
+		assert.Equal(t, testEnumStatusClosed, Cast[testEnumStatus]([]byte("closed")))
+		assert.Equal(t, testEnumColor("G"), Cast[testEnumColor]("Green"))
+		assert.Equal(t, testEnumColor("R"), Cast[testEnumColor]("R"))
+	})
+
+	t.Run("from_number", func(t *testing.T) {
+		assert.Equal(t, testEnumStatusActive, Cast[testEnumStatus](1))
+		assert.Equal(t, testEnumStatusClosed, Cast[testEnumStatus]("2"))
+		assert.Equal(t, testEnumStatusClosed, Cast[testEnumStatus](uint8(2)))
+		assert.Equal(t, testEnumStatusActive, Cast[testEnumStatus](1.0))
+		type level uint16
+		assert.Equal(t, testEnumStatusActive, Cast[testEnumStatus](level(1)))
+	})
+
+	t.Run("to_name", func(t *testing.T) {
+		assert.Equal(t, "active", Str(testEnumStatusActive))
+		assert.Equal(t, "closed", Str(testEnumStatusClosed))
+		assert.Equal(t, "active", Cast[string](testEnumStatusActive))
+		assert.Equal(t, "green", Str(testEnumColor("G")))
+		assert.Equal(t, []string{"draft", "closed"}, Slice[string]([]testEnumStatus{0, 2}))
+	})
+
+	t.Run("to_number", func(t *testing.T) {
+		assert.Equal(t, 1, Number[int](testEnumStatusActive))
+		assert.Equal(t, int64(2), Cast[int64](testEnumStatusClosed))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := TryCast[testEnumStatus]("unknown")
+		assert.ErrorIs(t, err, ErrInvalidEnumValue)
+		_, err = TryCast[testEnumStatus](10)
+		assert.ErrorIs(t, err, ErrInvalidEnumValue)
+		_, err = TryCast[testEnumColor](1)
+		assert.ErrorIs(t, err, ErrInvalidEnumValue)
+		_, err = TryCast[testEnumStatus](true)
+		assert.ErrorIs(t, err, ErrInvalidEnumValue)
+		_, err = TryCast[testEnumStatus](1.5)
+		assert.ErrorIs(t, err, ErrInvalidEnumValue)
+		_, err = TryCast[testEnumStatus]("1.5")
+		assert.ErrorIs(t, err, ErrInvalidEnumValue)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type item struct {
+			Status testEnumStatus `json:"status"`
+			Color  testEnumColor  `json:"color"`
+		}
+		var it item
+		assert.NoError(t, TryCopyStruct(&it, map[string]any{"status": "Active", "color": "red"}, "json"))
+		assert.Equal(t, item{Status: testEnumStatusActive, Color: "R"}, it)
+
+		err := TryCopyStruct(&it, map[string]any{"status": "removed"}, "json")
+		assert.ErrorIs(t, err, ErrInvalidEnumValue)
+
+		m, err := TryMap[string, string](it, "json")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"status": "active", "color": "red"}, m)
+	})
+
+	t.Run("tag", func(t *testing.T) {
+		type item struct {
+			Level int    `enum:"low,medium,high"`
+			State string `enum:"draft,active,closed"`
+		}
+		var it item
+		assert.NoError(t, TryCopyStruct(&it, map[string]any{"Level": "High", "State": "ACTIVE"}))
+		assert.Equal(t, item{Level: 2, State: "active"}, it)
+
+		assert.NoError(t, TryCopyStruct(&it, map[string]any{"Level": 1, "State": 2}))
+		assert.Equal(t, item{Level: 1, State: "closed"}, it)
+
+		err := TryCopyStruct(&it, map[string]any{"Level": "extreme"})
+		assert.ErrorIs(t, err, ErrInvalidEnumValue)
+		err = TryCopyStruct(&it, map[string]any{"Level": 3})
+		assert.ErrorIs(t, err, ErrInvalidEnumValue)
+		err = TryCopyStruct(&it, map[string]any{"State": "removed"})
+		assert.ErrorIs(t, err, ErrInvalidEnumValue)
+
+		// The nil values reset the fields
+		assert.NoError(t, TryCopyStruct(&it, map[string]any{"Level": (*int)(nil), "State": (*string)(nil)}))
+		assert.Equal(t, item{}, it)
+
+		it = item{Level: 1, State: "active"}
+		assert.NoError(t, TryCopyStruct(&it, map[string]any{"Level": nil, "State": nil}))
+		assert.Equal(t, item{}, it)
+
+		res, err := TryTransform[item](struct{ State *string }{})
+		assert.NoError(t, err)
+		assert.Equal(t, item{}, res)
+	})
+}
@@ -0,0 +1,251 @@
+package gocast
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// EqualOption defines the option of the Equal and EqualCast functions
+type EqualOption func(opts *equalOptions)
+
+type equalOptions struct {
+	tags       []string
+	ignore     [][]string
+	nilAsEmpty bool
+	tolerance  float64
+	cast       bool
+}
+
+// WithEqualTags sets the struct tags used to name fields, "json" by default
+func WithEqualTags(tags ...string) EqualOption {
+	return func(opts *equalOptions) {
+		opts.tags = tags
+	}
+}
+
+// WithEqualIgnore skips the values by paths like "user.updated_at",
+// the "*" segment matches any field, map key or slice index
+func WithEqualIgnore(paths ...string) EqualOption {
+	return func(opts *equalOptions) {
+		for _, path := range paths {
+			opts.ignore = append(opts.ignore, strings.Split(path, "."))
+		}
+	}
+}
+
+// WithEqualNilAsEmpty makes nil and absent values equal to the empty ones ("", 0, [], {})
+func WithEqualNilAsEmpty() EqualOption {
+	return func(opts *equalOptions) {
+		opts.nilAsEmpty = true
+	}
+}
+
+// WithEqualFloatTolerance compares floats with the absolute tolerance
+func WithEqualFloatTolerance(tolerance float64) EqualOption {
+	return func(opts *equalOptions) {
+		opts.tolerance = tolerance
+	}
+}
+
+type equaler struct {
+	opts    equalOptions
+	visited map[[2]uintptr]bool
+}
+
+// Equal compares two values recursively.
+// Structs are compared by exported fields, pointers and interfaces by the values,
+// types with `Equal(T) bool` method (like time.Time) by the method.
+//
+//	gocast.Equal(a, b, gocast.WithEqualIgnore("updated_at"), gocast.WithEqualFloatTolerance(1e-9))
+func Equal(a, b any, opts ...EqualOption) bool {
+	return newEqualer(false, opts...).equal(nil, reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+// EqualCast compares two values recursively like Equal, but values of different types
+// are normalised through the cast pipeline ("1" equals 1), and structs are compared
+// with maps by field names from the json tag.
+//
+//	gocast.EqualCast(map[string]any{"id": "1", "name": "Bob"}, User{ID: 1, Name: "Bob"}) // true
+func EqualCast(a, b any, opts ...EqualOption) bool {
+	return newEqualer(true, opts...).equal(nil, reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func newEqualer(cast bool, opts ...EqualOption) *equaler {
+	eq := &equaler{
+		opts:    equalOptions{tags: []string{"json"}, cast: cast},
+		visited: map[[2]uintptr]bool{},
+	}
+	for _, opt := range opts {
+		opt(&eq.opts)
+	}
+	return eq
+}
+
+func (eq *equaler) equal(path []string, a, b reflect.Value) bool {
+	if eq.isIgnored(path) {
+		return true
+	}
+
+	// Circular references are equal if the same pair of pointers is compared again
+	if a.IsValid() && b.IsValid() && a.Kind() == reflect.Pointer && b.Kind() == reflect.Pointer && !a.IsNil() && !b.IsNil() {
+		key := [2]uintptr{a.Pointer(), b.Pointer()}
+		if eq.visited[key] {
+			return true
+		}
+		eq.visited[key] = true
+	}
+
+	a, b = derefValue(a), derefValue(b)
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return true
+	case !a.IsValid():
+		return eq.opts.nilAsEmpty && IsEmptyByReflection(b)
+	case !b.IsValid():
+		return eq.opts.nilAsEmpty && IsEmptyByReflection(a)
+	}
+
+	if eq.opts.nilAsEmpty && IsEmptyByReflection(a) && IsEmptyByReflection(b) &&
+		(a.Type() == b.Type() || eq.opts.cast) {
+		return true
+	}
+
+	if eq.isRecord(a) && eq.isRecord(b) && (a.Type() == b.Type() || eq.opts.cast) {
+		return eq.equalRecords(path, a, b)
+	}
+	if eq.isList(a) && eq.isList(b) && (a.Type() == b.Type() || eq.opts.cast) {
+		return eq.equalLists(path, a, b)
+	}
+	if a.Type() == b.Type() {
+		if isFloatKind(a.Kind()) {
+			return eq.equalFloats(a.Float(), b.Float())
+		}
+		return valuesEqual(a, b)
+	}
+	if !eq.opts.cast {
+		return false
+	}
+	return eq.equalCast(a, b)
+}
+
+// equalCast compares scalar values of different types after the conversion
+func (eq *equaler) equalCast(a, b reflect.Value) bool {
+	if !a.CanInterface() || !b.CanInterface() {
+		return false
+	}
+	if isFloatKind(a.Kind()) || isFloatKind(b.Kind()) {
+		af, aErr := TryNumber[float64](a.Interface())
+		bf, bErr := TryNumber[float64](b.Interface())
+		return aErr == nil && bErr == nil && eq.equalFloats(af, bf)
+	}
+	ctx := context.Background()
+	if bv, err := ReflectTryToTypeContext(ctx, b, a.Type(), true, eq.opts.tags...); err == nil && bv != nil {
+		if valuesEqual(a, reflect.ValueOf(bv)) {
+			return true
+		}
+	}
+	if av, err := ReflectTryToTypeContext(ctx, a, b.Type(), true, eq.opts.tags...); err == nil && av != nil {
+		return valuesEqual(reflect.ValueOf(av), b)
+	}
+	return false
+}
+
+func (eq *equaler) equalFloats(a, b float64) bool {
+	if a == b || (math.IsNaN(a) && math.IsNaN(b)) {
+		return true
+	}
+	return math.Abs(a-b) <= eq.opts.tolerance
+}
+
+func (eq *equaler) equalRecords(path []string, a, b reflect.Value) bool {
+	if a.Kind() == reflect.Map && b.Kind() == reflect.Map && a.IsNil() != b.IsNil() {
+		return eq.opts.nilAsEmpty
+	}
+	aFields, bFields := eq.recordFields(a), eq.recordFields(b)
+	for name, aVal := range aFields {
+		if !eq.equal(appendPath(path, name), aVal, bFields[name]) {
+			return false
+		}
+	}
+	for name, bVal := range bFields {
+		if _, ok := aFields[name]; !ok && !eq.equal(appendPath(path, name), reflect.Value{}, bVal) {
+			return false
+		}
+	}
+	return true
+}
+
+func (eq *equaler) equalLists(path []string, a, b reflect.Value) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	if a.Kind() == reflect.Slice && b.Kind() == reflect.Slice && a.IsNil() != b.IsNil() {
+		return eq.opts.nilAsEmpty
+	}
+	for i := 0; i < a.Len(); i++ {
+		if !eq.equal(appendPath(path, Str(i)), a.Index(i), b.Index(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordFields returns the values of the struct fields or map items by names
+func (eq *equaler) recordFields(v reflect.Value) map[string]reflect.Value {
+	if v.Kind() == reflect.Map {
+		fields := make(map[string]reflect.Value, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			fields[Str(iter.Key().Interface())] = iter.Value()
+		}
+		return fields
+	}
+	fields := make(map[string]reflect.Value, v.NumField())
+	for _, ft := range ReflectStructFields(v.Type()) {
+		if !ft.IsExported() || (len(eq.opts.tags) > 0 && fieldTag(ft, eq.opts.tags[0]) == "") {
+			continue
+		}
+		name, _ := fieldNameFromTags(ft, eq.opts.tags...)
+		fields[name] = v.FieldByName(ft.Name)
+	}
+	return fields
+}
+
+func (eq *equaler) isRecord(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map:
+		return true
+	case reflect.Struct:
+		return isMergeableStruct(v.Type()) && !hasEqualMethod(v.Type())
+	}
+	return false
+}
+
+func (eq *equaler) isList(v reflect.Value) bool {
+	return (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type() != bytesType
+}
+
+func (eq *equaler) isIgnored(path []string) bool {
+	for _, ignore := range eq.opts.ignore {
+		if len(ignore) != len(path) {
+			continue
+		}
+		matched := true
+		for i, name := range ignore {
+			if name != "*" && name != path[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
@@ -0,0 +1,100 @@
+package gocast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEqualUser struct {
+	ID      int               `json:"id"`
+	Name    string            `json:"name"`
+	Score   float64           `json:"score"`
+	Tags    []string          `json:"tags"`
+	Address *testEqualAddress `json:"address"`
+	Updated time.Time         `json:"updated"`
+	Secret  string            `json:"-"`
+}
+
+type testEqualAddress struct {
+	City string `json:"city"`
+}
+
+func TestEqual(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	user := testEqualUser{ID: 1, Name: "Bob", Score: 0.3, Tags: []string{"a"}, Address: &testEqualAddress{City: "Oslo"}, Updated: now}
+
+	t.Run("strict", func(t *testing.T) {
+		other := user
+		other.Address = &testEqualAddress{City: "Oslo"}
+		other.Updated = now.In(time.FixedZone("X", 3600))
+		other.Secret = "ignored by json tag"
+		assert.True(t, Equal(user, other))
+		assert.True(t, Equal(&user, &other))
+		assert.False(t, Equal(1, "1"))
+		assert.False(t, Equal(1, int64(1)))
+		assert.False(t, Equal([]int{1, 2}, []int{1}))
+		assert.True(t, Equal(map[string]any{"a": []any{1}}, map[string]any{"a": []any{1}}))
+
+		other.Address.City = "Rome"
+		assert.False(t, Equal(user, other))
+		assert.True(t, Equal(user, other, WithEqualIgnore("address.city")))
+		assert.True(t, Equal([]testEqualUser{user}, []testEqualUser{other}, WithEqualIgnore("*.address")))
+	})
+
+	t.Run("nil_as_empty", func(t *testing.T) {
+		assert.False(t, Equal([]int(nil), []int{}))
+		assert.True(t, Equal([]int(nil), []int{}, WithEqualNilAsEmpty()))
+		assert.False(t, Equal(map[string]int(nil), map[string]int{}))
+		assert.True(t, Equal(map[string]int(nil), map[string]int{}, WithEqualNilAsEmpty()))
+		assert.True(t, Equal(map[string]any{"a": nil}, map[string]any{"a": ""}, WithEqualNilAsEmpty()))
+		assert.True(t, Equal(map[string]any{"a": 1}, map[string]any{"a": 1, "b": ""}, WithEqualNilAsEmpty()))
+		assert.False(t, Equal(map[string]any{"a": 1}, map[string]any{"a": 1, "b": ""}))
+	})
+
+	t.Run("float_tolerance", func(t *testing.T) {
+		a, b := 0.1, 0.2
+		assert.False(t, Equal(a+b, 0.3))
+		assert.True(t, Equal(a+b, 0.3, WithEqualFloatTolerance(1e-9)))
+		other := user
+		other.Score = a + b
+		assert.True(t, Equal(user, other, WithEqualFloatTolerance(1e-9)))
+	})
+
+	t.Run("cast", func(t *testing.T) {
+		assert.True(t, EqualCast(1, "1"))
+		assert.True(t, EqualCast("true", true))
+		assert.True(t, EqualCast(int64(2), 2.0))
+		assert.False(t, EqualCast(1, "2"))
+		assert.False(t, EqualCast("abc", 1))
+
+		decoded := map[string]any{
+			"id":      "1",
+			"name":    "Bob",
+			"score":   "0.3",
+			"tags":    []any{"a"},
+			"address": map[string]any{"city": "Oslo"},
+			"updated": now.Format(time.RFC3339),
+		}
+		assert.True(t, EqualCast(decoded, user))
+		assert.True(t, EqualCast(user, decoded))
+
+		decoded["address"] = map[string]any{"city": "Rome"}
+		assert.False(t, EqualCast(decoded, user))
+		assert.True(t, EqualCast(decoded, user, WithEqualIgnore("address")))
+		assert.False(t, Equal(decoded, user, WithEqualIgnore("address")))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		type node struct {
+			Value int
+			Next  *node
+		}
+		a, b := &node{Value: 1}, &node{Value: 1}
+		a.Next, b.Next = a, b
+		assert.True(t, Equal(a, b))
+		b.Value = 2
+		assert.False(t, Equal(a, b))
+	})
+}
@@ -1,6 +1,7 @@
 package gocast
 
 import (
+	"context"
 	"errors"
 )
 
@@ -19,6 +20,27 @@ func wrapError(err error, msg string) error {
 	return &errorWrapper{err: err, msg: msg}
 }
 
+// contextCheckInterval is the number of loop iterations between the checks of the context
+const contextCheckInterval = 64
+
+// contextError returns the error of the canceled context every contextCheckInterval
+// iterations of the loop, the caller wraps it with the element reached by the loop
+func contextError(ctx context.Context, i int) error {
+	if i%contextCheckInterval != 0 {
+		return nil
+	}
+	return ctx.Err()
+}
+
+// wrapContextError wraps the context error with the path of the element,
+// other errors are returned as is
+func wrapContextError(err error, msg string) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return wrapError(err, msg)
+	}
+	return err
+}
+
 // Error list...
 var (
 	ErrInvalidParams                 = errors.New("invalid params")
@@ -28,6 +50,14 @@ var (
 	ErrUnsupportedNumericType        = errors.New("unsupported numeric type")
 	ErrStructFieldNameUndefined      = errors.New("struct field name undefined")
 	ErrStructFieldValueCantBeChanged = errors.New("struct field value cant be changed")
+	ErrInvalidEnumValue              = errors.New("invalid enum value")
+	ErrInvalidPatch                  = errors.New("invalid patch")
+	ErrPatchPathNotFound             = errors.New("patch path not found")
+	ErrPatchTestFailed               = errors.New("patch test failed")
+	ErrValidationFailed              = errors.New("validation failed")
+	ErrUnknownValidationRule         = errors.New("unknown validation rule")
+	ErrUnknownModifier               = errors.New("unknown modifier")
+	ErrLengthMismatch                = errors.New("length mismatch")
 	// Deprecated: ErrCopyCircularReference is never returned by the library;
 	// circular references are handled transparently via a visited-pointer map.
 	// This sentinel will be removed in v3.
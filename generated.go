@@ -0,0 +1,72 @@
+package gocast
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// MapCopier is implemented by the types with converters generated by cmd/gocastgen.
+// TryCopyStructContext calls CopyFromContext instead of the reflection for map[string]any sources
+// if the tags of the call are the same as the tags of the generation, the context
+// options like ContextWithSliceCoercion are passed to the conversions of the fields.
+type MapCopier interface {
+	GocastTags() []string
+	CopyFromContext(ctx context.Context, src map[string]any) error
+}
+
+// MapExporter is implemented by the types with converters generated by cmd/gocastgen.
+// TryMapCopyContext uses ToMap instead of the reflection to get the struct fields
+// if the tags of the call are the same as the tags of the generation.
+type MapExporter interface {
+	GocastTags() []string
+	ToMap() map[string]any
+}
+
+// generatedMapCopier returns the generated converter of the destination for the tags
+func generatedMapCopier(dst any, tags []string) (MapCopier, bool) {
+	copier, ok := dst.(MapCopier)
+	return copier, ok && stringsEqual(copier.GocastTags(), tags)
+}
+
+// generatedMapExporter returns the generated converter of the source for the tags
+func generatedMapExporter(src any, tags []string) (MapExporter, bool) {
+	exporter, ok := src.(MapExporter)
+	return exporter, ok && stringsEqual(exporter.GocastTags(), tags)
+}
+
+// CopyState is used by DeepCopy generated by cmd/gocastgen to share the copies
+// of the pointers between the fields, the fields referring to the same value
+// refer to the same copy like with TryCopy
+type CopyState struct {
+	visited map[uintptr]reflect.Value
+}
+
+// CopyField returns the copy of the struct field value for DeepCopy generated by cmd/gocastgen.
+// The action is defined by the `copy` tag of the field, the unexported fields holding
+// channels or functions are shared like by TryCopy.
+func CopyField[T any](state *CopyState, src T, action CopyAction, exported bool) (dst T, err error) {
+	switch action {
+	case CopyActionSkip:
+		return dst, nil
+	case CopyActionShallow:
+		return src, nil
+	}
+	if state.visited == nil {
+		state.visited = make(map[uintptr]reflect.Value)
+	}
+	var (
+		ctx    = context.Background()
+		srcVal = reflect.ValueOf(&src).Elem()
+		dstVal = reflect.ValueOf(&dst).Elem()
+	)
+	if action == CopyActionDeep {
+		err = deepCopyValue(ctx, srcVal, dstVal, state.visited)
+	} else {
+		err = deepCopy(ctx, srcVal, dstVal, state.visited)
+	}
+	if err != nil && !exported && errors.Is(err, ErrCopyUnsupportedType) {
+		return src, nil
+	}
+	return dst, err
+}
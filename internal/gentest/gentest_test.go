@@ -0,0 +1,256 @@
+package gentest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/demdxx/gocast/v2"
+)
+
+// The twin types have the same fields without generated methods
+// so gocast converts them by the reflection
+type (
+	userPlain    User
+	nodePlain    Node
+	profilePlain Profile
+)
+
+func testUser() User {
+	return User{
+		ID:       100,
+		Name:     "Alice",
+		Score:    9.5,
+		Active:   true,
+		Tags:     []string{"a", "b"},
+		Attrs:    map[string]int{"x": 1},
+		Address:  Address{City: "Paris", Zip: 75001},
+		Backup:   &Address{City: "Lyon"},
+		Created:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Nick:     gocast.Optional[string]{},
+		Internal: "internal",
+		NoTag:    7,
+		Extra:    []any{1, "2"},
+		password: "secret",
+	}
+}
+
+func TestGeneratedInterfaces(t *testing.T) {
+	var (
+		_ gocast.MapCopier   = (*User)(nil)
+		_ gocast.MapExporter = User{}
+		_ gocast.MapCopier   = (*Node)(nil)
+		_ gocast.MapExporter = (*Counter)(nil)
+	)
+	_, hasDeepCopy := any(Profile{}).(interface{ DeepCopy() (Profile, error) })
+	assert.True(t, hasDeepCopy)
+
+	for _, v := range []any{User{}, Node{}, Parent{}, Child{}, &Counter{}} {
+		_, hasDeepCopy = v.(interface{ DeepCopy() })
+		assert.False(t, hasDeepCopy, "%T must be copied by the reflection", v)
+		_, hasDeepCopy = reflect.TypeOf(v).MethodByName("DeepCopy")
+		assert.False(t, hasDeepCopy, "%T must be copied by the reflection", v)
+	}
+}
+
+func TestGeneratedCopyFrom(t *testing.T) {
+	tests := []struct {
+		name string
+		src  map[string]any
+	}{
+		{
+			name: "tag names",
+			src: map[string]any{
+				"id": "42", "name": "Bob", "email": "bob@mail.com", "score": "1.5",
+				"active": "true", "tags": []any{"x", 1}, "attrs": map[string]any{"k": "2"},
+				"address": map[string]any{"city": "Berlin", "zip": "10115"},
+				"backup":  map[string]any{"city": "Bonn"},
+				"created": "2024-01-02T03:04:05Z", "nick": "bobby",
+				"Internal": "int", "NoTag": 3, "extra": 1.5,
+			},
+		},
+		{
+			name: "field names",
+			src:  map[string]any{"ID": 1, "Name": "Carl", "Tags": []string{"t"}, "Nick": nil},
+		},
+		{
+			name: "empty",
+			src:  map[string]any{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				gen   User
+				plain userPlain
+			)
+			genErr := gocast.TryCopyStruct(&gen, test.src, "json")
+			plainErr := gocast.TryCopyStruct(&plain, test.src, "json")
+			assert.Equal(t, plainErr, genErr)
+			assert.Equal(t, User(plain), gen)
+
+			var direct User
+			assert.NoError(t, direct.CopyFrom(test.src))
+			assert.Equal(t, gen, direct)
+		})
+	}
+
+	t.Run("other tags", func(t *testing.T) {
+		// The generated converter is not used for the different tags
+		var gen User
+		assert.NoError(t, gocast.TryCopyStruct(&gen, map[string]any{"id": 1, "ID": 2}))
+		assert.Equal(t, int64(2), gen.ID)
+	})
+
+	t.Run("context", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			coerce   = gocast.ContextWithSliceCoercion(ctx, gocast.WithSliceSeparator(","))
+			truncate = gocast.ContextWithArrayLength(ctx, gocast.ArrayLengthTruncate)
+			canceled context.Context
+			cancel   context.CancelFunc
+		)
+		canceled, cancel = context.WithCancel(ctx)
+		cancel()
+		tests := []struct {
+			name string
+			ctx  context.Context
+			src  map[string]any
+		}{
+			{name: "slice coercion", ctx: coerce, src: map[string]any{"tags": "a, b"}},
+			{name: "array length", ctx: truncate, src: map[string]any{"codes": []int{1, 2, 3}}},
+			{name: "array mismatch", ctx: ctx, src: map[string]any{"codes": []int{1, 2, 3}}},
+			{name: "canceled", ctx: canceled, src: map[string]any{"name": "a"}},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				var (
+					gen   Profile
+					plain profilePlain
+				)
+				genErr := gocast.TryCopyStructContext(test.ctx, &gen, test.src, "json")
+				plainErr := gocast.TryCopyStructContext(test.ctx, &plain, test.src, "json")
+				if plainErr != nil {
+					assert.EqualError(t, genErr, plainErr.Error())
+				} else {
+					assert.NoError(t, genErr)
+				}
+				assert.Equal(t, Profile(plain), gen)
+			})
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var (
+			gen   User
+			plain userPlain
+			src   = map[string]any{"score": "invalid"}
+		)
+		assert.Error(t, gocast.TryCopyStruct(&gen, src, "json"))
+		assert.Error(t, gocast.TryCopyStruct(&plain, src, "json"))
+	})
+}
+
+func TestGeneratedToMap(t *testing.T) {
+	users := []User{testUser(), {Email: "a@b.c", Nick: gocast.Optional[string]{}}, {}}
+	users[1].Nick.Set("nick")
+	for _, user := range users {
+		gen, err := gocast.TryMap[string, any](user, "json")
+		assert.NoError(t, err)
+		plain, err := gocast.TryMap[string, any](userPlain(user), "json")
+		assert.NoError(t, err)
+		assert.Equal(t, plain, gen)
+
+		genStr, err := gocast.TryMap[string, string](user, "json")
+		assert.NoError(t, err)
+		plainStr, err := gocast.TryMap[string, string](userPlain(user), "json")
+		assert.NoError(t, err)
+		assert.Equal(t, plainStr, genStr)
+	}
+}
+
+func TestGeneratedDeepCopy(t *testing.T) {
+	backup := &Address{City: "Lyon"}
+	profile := Profile{
+		Name:    "Alice",
+		Tags:    []string{"a", "b"},
+		Scores:  map[string][]int{"x": {1, 2}},
+		Codes:   [2]int{1, 2},
+		Address: Address{City: "Paris", Zip: 75001},
+		Backup:  backup,
+		Primary: backup,
+		Cache:   &Address{City: "Cache"},
+		Temp:    []int{1},
+		Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		note:    "note",
+	}
+	gen, err := gocast.TryCopy(profile)
+	assert.NoError(t, err)
+	plain, err := gocast.TryCopy(profilePlain(profile))
+	assert.NoError(t, err)
+	assert.Equal(t, Profile(plain), gen)
+	direct, err := profile.DeepCopy()
+	assert.NoError(t, err)
+	assert.Equal(t, gen, direct)
+
+	withOpts, err := gocast.TryCopyWithOptions(profile, gocast.CopyOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, gen, withOpts)
+
+	// The copy tags and the shared pointers are kept like by the reflection
+	for _, res := range []Profile{gen, Profile(plain), direct, withOpts} {
+		assert.Same(t, profile.Cache, res.Cache)
+		assert.Nil(t, res.Temp)
+		assert.Same(t, res.Backup, res.Primary)
+		assert.NotSame(t, profile.Backup, res.Backup)
+	}
+
+	gen.Tags[0] = "changed"
+	gen.Scores["x"][0] = 3
+	gen.Backup.City = "changed"
+	assert.Equal(t, "a", profile.Tags[0])
+	assert.Equal(t, 1, profile.Scores["x"][0])
+	assert.Equal(t, "Lyon", profile.Backup.City)
+
+	user := testUser()
+	user.Extra = &user
+	userCopy, err := gocast.TryCopy(user)
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice", userCopy.Extra.(*User).Name)
+	assert.NotSame(t, &user, userCopy.Extra)
+
+	node := Node{Name: "a", Next: &Node{Name: "b"}}
+	nodeCopy, err := gocast.TryCopy(node)
+	assert.NoError(t, err)
+	plainNode, err := gocast.TryCopy(nodePlain(node))
+	assert.NoError(t, err)
+	assert.Equal(t, Node(plainNode), nodeCopy)
+	assert.NotSame(t, node.Next, nodeCopy.Next)
+
+	parent := &Parent{Name: "p"}
+	parent.Child = &Child{Name: "c", Parent: parent}
+	parentCopy, err := gocast.TryCopy(parent)
+	assert.NoError(t, err)
+	assert.NotSame(t, parent, parentCopy)
+	assert.Same(t, parentCopy, parentCopy.Child.Parent)
+
+	counter := &Counter{Name: "c", Count: 2}
+	m, err := gocast.TryMap[string, any](counter, "json")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "c", "count": 2}, m)
+}
+
+func TestGeneratedConvert(t *testing.T) {
+	user := testUser()
+	gen, err := user.ToUserDTO()
+	assert.NoError(t, err)
+
+	var plain UserDTO
+	assert.NoError(t, gocast.TryCopyStructContext(context.Background(), &plain, userPlain(user), "json"))
+	assert.Equal(t, plain, gen)
+	assert.Equal(t, "100", gen.ID)
+	assert.Equal(t, map[string]any{"city": "Paris", "zip": 75001}, gen.Address)
+}
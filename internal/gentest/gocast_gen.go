@@ -0,0 +1,525 @@
+// Code generated by gocastgen. DO NOT EDIT.
+
+package gentest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/demdxx/gocast/v2"
+)
+
+// GocastTags returns the tags used to generate the converters of Address
+func (Address) GocastTags() []string { return []string{"json"} }
+
+// CopyFrom puts the values of the map into the fields of Address
+func (v *Address) CopyFrom(src map[string]any) error {
+	return v.CopyFromContext(context.Background(), src)
+}
+
+// CopyFromContext puts the values of the map into the fields of Address,
+// the options of the context are used by the conversions of the fields
+func (v *Address) CopyFromContext(ctx context.Context, src map[string]any) (err error) {
+	var (
+		val any
+		ok  bool
+	)
+	if err = ctx.Err(); err != nil {
+		return fmt.Errorf("City: %w", err)
+	}
+	val, ok = src["city"]
+	if !ok {
+		val, ok = src["City"]
+	}
+	if v.City, err = gocast.TryCastContext[string](ctx, val, "json"); err != nil {
+		return fmt.Errorf("City: %w", err)
+	}
+	val, ok = src["zip"]
+	if !ok {
+		val, ok = src["Zip"]
+	}
+	if v.Zip, err = gocast.TryCastContext[int](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Zip: %w", err)
+	}
+	return nil
+}
+
+// ToMap returns the map of the fields of Address
+func (v Address) ToMap() map[string]any {
+	m := make(map[string]any, 2)
+	m["city"] = v.City
+	if !gocast.IsEmpty(v.Zip) {
+		m["zip"] = v.Zip
+	}
+	return m
+}
+
+// DeepCopy returns the deep copy of Address
+func (v Address) DeepCopy() (dst Address, err error) {
+	dst.City = v.City
+	dst.Zip = v.Zip
+	return dst, nil
+}
+
+// GocastTags returns the tags used to generate the converters of Child
+func (Child) GocastTags() []string { return []string{"json"} }
+
+// CopyFrom puts the values of the map into the fields of Child
+func (v *Child) CopyFrom(src map[string]any) error {
+	return v.CopyFromContext(context.Background(), src)
+}
+
+// CopyFromContext puts the values of the map into the fields of Child,
+// the options of the context are used by the conversions of the fields
+func (v *Child) CopyFromContext(ctx context.Context, src map[string]any) (err error) {
+	var (
+		val any
+		ok  bool
+	)
+	if err = ctx.Err(); err != nil {
+		return fmt.Errorf("Name: %w", err)
+	}
+	val, ok = src["name"]
+	if !ok {
+		val, ok = src["Name"]
+	}
+	if v.Name, err = gocast.TryCastContext[string](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Name: %w", err)
+	}
+	val, ok = src["parent"]
+	if !ok {
+		val, ok = src["Parent"]
+	}
+	if v.Parent, err = gocast.TryCastContext[*Parent](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Parent: %w", err)
+	}
+	return nil
+}
+
+// ToMap returns the map of the fields of Child
+func (v Child) ToMap() map[string]any {
+	m := make(map[string]any, 2)
+	m["name"] = v.Name
+	m["parent"] = v.Parent
+	return m
+}
+
+// GocastTags returns the tags used to generate the converters of Counter
+func (*Counter) GocastTags() []string { return []string{"json"} }
+
+// CopyFrom puts the values of the map into the fields of Counter
+func (v *Counter) CopyFrom(src map[string]any) error {
+	return v.CopyFromContext(context.Background(), src)
+}
+
+// CopyFromContext puts the values of the map into the fields of Counter,
+// the options of the context are used by the conversions of the fields
+func (v *Counter) CopyFromContext(ctx context.Context, src map[string]any) (err error) {
+	var (
+		val any
+		ok  bool
+	)
+	if err = ctx.Err(); err != nil {
+		return fmt.Errorf("Name: %w", err)
+	}
+	val, ok = src["name"]
+	if !ok {
+		val, ok = src["Name"]
+	}
+	if v.Name, err = gocast.TryCastContext[string](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Name: %w", err)
+	}
+	val, ok = src["count"]
+	if !ok {
+		val, ok = src["Count"]
+	}
+	if v.Count, err = gocast.TryCastContext[int](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Count: %w", err)
+	}
+	return nil
+}
+
+// ToMap returns the map of the fields of Counter
+func (v *Counter) ToMap() map[string]any {
+	m := make(map[string]any, 3)
+	m["name"] = v.Name
+	m["count"] = v.Count
+	return m
+}
+
+// GocastTags returns the tags used to generate the converters of Node
+func (Node) GocastTags() []string { return []string{"json"} }
+
+// CopyFrom puts the values of the map into the fields of Node
+func (v *Node) CopyFrom(src map[string]any) error {
+	return v.CopyFromContext(context.Background(), src)
+}
+
+// CopyFromContext puts the values of the map into the fields of Node,
+// the options of the context are used by the conversions of the fields
+func (v *Node) CopyFromContext(ctx context.Context, src map[string]any) (err error) {
+	var (
+		val any
+		ok  bool
+	)
+	if err = ctx.Err(); err != nil {
+		return fmt.Errorf("Name: %w", err)
+	}
+	val, ok = src["name"]
+	if !ok {
+		val, ok = src["Name"]
+	}
+	if v.Name, err = gocast.TryCastContext[string](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Name: %w", err)
+	}
+	val, ok = src["next"]
+	if !ok {
+		val, ok = src["Next"]
+	}
+	if v.Next, err = gocast.TryCastContext[*Node](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Next: %w", err)
+	}
+	return nil
+}
+
+// ToMap returns the map of the fields of Node
+func (v Node) ToMap() map[string]any {
+	m := make(map[string]any, 2)
+	m["name"] = v.Name
+	m["next"] = v.Next
+	return m
+}
+
+// GocastTags returns the tags used to generate the converters of Parent
+func (Parent) GocastTags() []string { return []string{"json"} }
+
+// CopyFrom puts the values of the map into the fields of Parent
+func (v *Parent) CopyFrom(src map[string]any) error {
+	return v.CopyFromContext(context.Background(), src)
+}
+
+// CopyFromContext puts the values of the map into the fields of Parent,
+// the options of the context are used by the conversions of the fields
+func (v *Parent) CopyFromContext(ctx context.Context, src map[string]any) (err error) {
+	var (
+		val any
+		ok  bool
+	)
+	if err = ctx.Err(); err != nil {
+		return fmt.Errorf("Name: %w", err)
+	}
+	val, ok = src["name"]
+	if !ok {
+		val, ok = src["Name"]
+	}
+	if v.Name, err = gocast.TryCastContext[string](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Name: %w", err)
+	}
+	val, ok = src["child"]
+	if !ok {
+		val, ok = src["Child"]
+	}
+	if v.Child, err = gocast.TryCastContext[*Child](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Child: %w", err)
+	}
+	return nil
+}
+
+// ToMap returns the map of the fields of Parent
+func (v Parent) ToMap() map[string]any {
+	m := make(map[string]any, 2)
+	m["name"] = v.Name
+	m["child"] = v.Child
+	return m
+}
+
+// GocastTags returns the tags used to generate the converters of Profile
+func (Profile) GocastTags() []string { return []string{"json"} }
+
+// CopyFrom puts the values of the map into the fields of Profile
+func (v *Profile) CopyFrom(src map[string]any) error {
+	return v.CopyFromContext(context.Background(), src)
+}
+
+// CopyFromContext puts the values of the map into the fields of Profile,
+// the options of the context are used by the conversions of the fields
+func (v *Profile) CopyFromContext(ctx context.Context, src map[string]any) (err error) {
+	var (
+		val any
+		ok  bool
+	)
+	if err = ctx.Err(); err != nil {
+		return fmt.Errorf("Name: %w", err)
+	}
+	val, ok = src["name"]
+	if !ok {
+		val, ok = src["Name"]
+	}
+	if v.Name, err = gocast.TryCastContext[string](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Name: %w", err)
+	}
+	val, ok = src["tags"]
+	if !ok {
+		val, ok = src["Tags"]
+	}
+	if v.Tags, err = gocast.TryCastContext[[]string](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Tags: %w", err)
+	}
+	val, ok = src["scores"]
+	if !ok {
+		val, ok = src["Scores"]
+	}
+	if v.Scores, err = gocast.TryCastContext[map[string][]int](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Scores: %w", err)
+	}
+	val, ok = src["codes"]
+	if !ok {
+		val, ok = src["Codes"]
+	}
+	if v.Codes, err = gocast.TryCastContext[[2]int](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Codes: %w", err)
+	}
+	val, ok = src["address"]
+	if !ok {
+		val, ok = src["Address"]
+	}
+	if v.Address, err = gocast.TryCastContext[Address](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Address: %w", err)
+	}
+	val, ok = src["backup"]
+	if !ok {
+		val, ok = src["Backup"]
+	}
+	if v.Backup, err = gocast.TryCastContext[*Address](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Backup: %w", err)
+	}
+	val, ok = src["primary"]
+	if !ok {
+		val, ok = src["Primary"]
+	}
+	if v.Primary, err = gocast.TryCastContext[*Address](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Primary: %w", err)
+	}
+	val, ok = src["Cache"]
+	if v.Cache, err = gocast.TryCastContext[*Address](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Cache: %w", err)
+	}
+	val, ok = src["Temp"]
+	if v.Temp, err = gocast.TryCastContext[[]int](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Temp: %w", err)
+	}
+	val, ok = src["created"]
+	if !ok {
+		val, ok = src["Created"]
+	}
+	if v.Created, err = gocast.TryCastContext[time.Time](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Created: %w", err)
+	}
+	return nil
+}
+
+// ToMap returns the map of the fields of Profile
+func (v Profile) ToMap() map[string]any {
+	m := make(map[string]any, 11)
+	m["name"] = v.Name
+	m["tags"] = v.Tags
+	m["scores"] = v.Scores
+	m["codes"] = v.Codes
+	m["address"] = v.Address
+	m["backup"] = v.Backup
+	m["primary"] = v.Primary
+	m["Cache"] = v.Cache
+	m["Temp"] = v.Temp
+	m["created"] = v.Created
+	return m
+}
+
+// DeepCopy returns the deep copy of Profile
+func (v Profile) DeepCopy() (dst Profile, err error) {
+	dst.Name = v.Name
+	var state gocast.CopyState
+	if dst.Tags, err = gocast.CopyField(&state, v.Tags, gocast.CopyActionDefault, true); err != nil {
+		return dst, err
+	}
+	if dst.Scores, err = gocast.CopyField(&state, v.Scores, gocast.CopyActionDefault, true); err != nil {
+		return dst, err
+	}
+	if dst.Codes, err = gocast.CopyField(&state, v.Codes, gocast.CopyActionDefault, true); err != nil {
+		return dst, err
+	}
+	if dst.Address, err = gocast.CopyField(&state, v.Address, gocast.CopyActionDefault, true); err != nil {
+		return dst, err
+	}
+	if dst.Backup, err = gocast.CopyField(&state, v.Backup, gocast.CopyActionDefault, true); err != nil {
+		return dst, err
+	}
+	if dst.Primary, err = gocast.CopyField(&state, v.Primary, gocast.CopyActionDefault, true); err != nil {
+		return dst, err
+	}
+	dst.Cache = v.Cache
+	if dst.Created, err = gocast.CopyField(&state, v.Created, gocast.CopyActionDefault, true); err != nil {
+		return dst, err
+	}
+	dst.note = v.note
+	return dst, nil
+}
+
+// GocastTags returns the tags used to generate the converters of User
+func (User) GocastTags() []string { return []string{"json"} }
+
+// CopyFrom puts the values of the map into the fields of User
+func (v *User) CopyFrom(src map[string]any) error {
+	return v.CopyFromContext(context.Background(), src)
+}
+
+// CopyFromContext puts the values of the map into the fields of User,
+// the options of the context are used by the conversions of the fields
+func (v *User) CopyFromContext(ctx context.Context, src map[string]any) (err error) {
+	var (
+		val any
+		ok  bool
+	)
+	if err = ctx.Err(); err != nil {
+		return fmt.Errorf("ID: %w", err)
+	}
+	val, ok = src["id"]
+	if !ok {
+		val, ok = src["ID"]
+	}
+	if v.ID, err = gocast.TryCastContext[int64](ctx, val, "json"); err != nil {
+		return fmt.Errorf("ID: %w", err)
+	}
+	val, ok = src["name"]
+	if !ok {
+		val, ok = src["Name"]
+	}
+	if v.Name, err = gocast.TryCastContext[string](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Name: %w", err)
+	}
+	val, ok = src["email"]
+	if !ok {
+		val, ok = src["Email"]
+	}
+	if v.Email, err = gocast.TryCastContext[string](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Email: %w", err)
+	}
+	val, ok = src["score"]
+	if !ok {
+		val, ok = src["Score"]
+	}
+	if v.Score, err = gocast.TryCastContext[float64](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Score: %w", err)
+	}
+	val, ok = src["active"]
+	if !ok {
+		val, ok = src["Active"]
+	}
+	if v.Active, err = gocast.TryCastContext[bool](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Active: %w", err)
+	}
+	val, ok = src["tags"]
+	if !ok {
+		val, ok = src["Tags"]
+	}
+	if v.Tags, err = gocast.TryCastContext[[]string](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Tags: %w", err)
+	}
+	val, ok = src["attrs"]
+	if !ok {
+		val, ok = src["Attrs"]
+	}
+	if v.Attrs, err = gocast.TryCastContext[map[string]int](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Attrs: %w", err)
+	}
+	val, ok = src["address"]
+	if !ok {
+		val, ok = src["Address"]
+	}
+	if v.Address, err = gocast.TryCastContext[Address](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Address: %w", err)
+	}
+	val, ok = src["backup"]
+	if !ok {
+		val, ok = src["Backup"]
+	}
+	if v.Backup, err = gocast.TryCastContext[*Address](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Backup: %w", err)
+	}
+	val, ok = src["created"]
+	if !ok {
+		val, ok = src["Created"]
+	}
+	if v.Created, err = gocast.TryCastContext[time.Time](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Created: %w", err)
+	}
+	val, ok = src["nick"]
+	if !ok {
+		val, ok = src["Nick"]
+	}
+	if val == nil && ok {
+		v.Nick.SetNull()
+	} else if v.Nick, err = gocast.TryCastContext[gocast.Optional[string]](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Nick: %w", err)
+	}
+	val, ok = src["Internal"]
+	if v.Internal, err = gocast.TryCastContext[string](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Internal: %w", err)
+	}
+	val, ok = src["NoTag"]
+	if v.NoTag, err = gocast.TryCastContext[int](ctx, val, "json"); err != nil {
+		return fmt.Errorf("NoTag: %w", err)
+	}
+	val, ok = src["extra"]
+	if !ok {
+		val, ok = src["Extra"]
+	}
+	if v.Extra, err = gocast.TryCastContext[any](ctx, val, "json"); err != nil {
+		return fmt.Errorf("Extra: %w", err)
+	}
+	return nil
+}
+
+// ToMap returns the map of the fields of User
+func (v User) ToMap() map[string]any {
+	m := make(map[string]any, 15)
+	m["id"] = v.ID
+	m["name"] = v.Name
+	if !gocast.IsEmpty(v.Email) {
+		m["email"] = v.Email
+	}
+	m["score"] = v.Score
+	m["active"] = v.Active
+	m["tags"] = v.Tags
+	m["attrs"] = v.Attrs
+	m["address"] = v.Address
+	m["backup"] = v.Backup
+	m["created"] = v.Created
+	if v.Nick.IsSet() {
+		m["nick"] = v.Nick
+	}
+	m["Internal"] = v.Internal
+	m["NoTag"] = v.NoTag
+	m["extra"] = v.Extra
+	return m
+}
+
+// ToUserDTO converts User into UserDTO
+func (v User) ToUserDTO() (dst UserDTO, err error) {
+	if dst.ID, err = gocast.TryCast[string](v.ID, "json"); err != nil {
+		return dst, fmt.Errorf("ID: %w", err)
+	}
+	dst.Name = v.Name
+	if dst.Score, err = gocast.TryCast[string](v.Score, "json"); err != nil {
+		return dst, fmt.Errorf("Score: %w", err)
+	}
+	dst.Tags = v.Tags
+	if dst.Attrs, err = gocast.TryCast[map[string]string](v.Attrs, "json"); err != nil {
+		return dst, fmt.Errorf("Attrs: %w", err)
+	}
+	if dst.Address, err = gocast.TryCast[map[string]any](v.Address, "json"); err != nil {
+		return dst, fmt.Errorf("Address: %w", err)
+	}
+	dst.Created = v.Created
+	return dst, nil
+}
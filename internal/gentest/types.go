@@ -0,0 +1,105 @@
+// Package gentest contains the types with converters generated by cmd/gocastgen
+// to check that generated and reflective conversions produce the same results.
+package gentest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/demdxx/gocast/v2"
+)
+
+//go:generate go run ../../cmd/gocastgen -tags json
+
+// Address is the nested struct of User
+//
+//gocast:generate
+type Address struct {
+	City string `json:"city"`
+	Zip  int    `json:"zip,omitempty"`
+}
+
+// User is the struct with the fields of different kinds,
+// it is copied by the reflection because of the interface field
+//
+//gocast:generate
+//gocast:convert UserDTO
+type User struct {
+	ID       int64                   `json:"id"`
+	Name     string                  `json:"name"`
+	Email    string                  `json:"email,omitempty"`
+	Score    float64                 `json:"score"`
+	Active   bool                    `json:"active"`
+	Tags     []string                `json:"tags"`
+	Attrs    map[string]int          `json:"attrs"`
+	Address  Address                 `json:"address"`
+	Backup   *Address                `json:"backup"`
+	Created  time.Time               `json:"created"`
+	Nick     gocast.Optional[string] `json:"nick,omitempty"`
+	Internal string                  `json:"-"`
+	NoTag    int
+	Extra    any `json:"extra"`
+	password string
+}
+
+// UserDTO is the target of the generated User converter
+type UserDTO struct {
+	ID      string            `json:"id"`
+	Name    string            `json:"name"`
+	Score   string            `json:"score"`
+	Tags    []string          `json:"tags"`
+	Attrs   map[string]string `json:"attrs"`
+	Address map[string]any    `json:"address"`
+	Created time.Time         `json:"created"`
+	Missing string            `json:"missing"`
+}
+
+// Node is the self-referencing struct copied by the reflection
+//
+//gocast:generate
+type Node struct {
+	Name string `json:"name"`
+	Next *Node  `json:"next"`
+}
+
+// Profile is the struct copied by the generated DeepCopy
+//
+//gocast:generate
+type Profile struct {
+	Name    string           `json:"name"`
+	Tags    []string         `json:"tags"`
+	Scores  map[string][]int `json:"scores"`
+	Codes   [2]int           `json:"codes"`
+	Address Address          `json:"address"`
+	Backup  *Address         `json:"backup"`
+	Primary *Address         `json:"primary"`
+	Cache   *Address         `json:"-" copy:"shallow"`
+	Temp    []int            `json:"-" copy:"-"`
+	Created time.Time        `json:"created"`
+	note    string
+}
+
+// Parent and Child refer to each other and are copied by the reflection
+//
+//gocast:generate
+type Parent struct {
+	Name  string `json:"name"`
+	Child *Child `json:"child"`
+}
+
+// Child is the item of Parent which refers back to it
+//
+//gocast:generate
+type Child struct {
+	Name   string  `json:"name"`
+	Parent *Parent `json:"parent"`
+}
+
+// Counter holds the lock, its methods use the pointer receiver
+//
+//gocast:generate
+type Counter struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+	mu    sync.Mutex
+}
@@ -43,7 +43,10 @@ func TryMapCopyContext[K comparable, V any](ctx context.Context, dst map[K]V, sr
 	)
 	switch srcType.Kind() {
 	case reflect.Map:
-		for _, k := range srcVal.MapKeys() {
+		for i, k := range srcVal.MapKeys() {
+			if err := contextError(ctx, i); err != nil {
+				return wrapError(err, `"`+Str(k.Interface())+`" map key`)
+			}
 			field := srcVal.MapIndex(k)
 			key, err := TryCast[K](k.Interface())
 			if err == nil {
@@ -58,16 +61,27 @@ func TryMapCopyContext[K comparable, V any](ctx context.Context, dst map[K]V, sr
 			}
 		}
 	case reflect.Struct:
+		valType := reflect.TypeOf((*V)(nil)).Elem()
+		if exporter, ok := generatedMapExporter(src, tags); ok {
+			return mapCopyGenerated(ctx, dst, exporter.ToMap(), valType, recursive, tags...)
+		}
 		for i := 0; i < srcVal.NumField(); i++ {
+			if err := contextError(ctx, i); err != nil {
+				return wrapError(err, srcType.Field(i).Name)
+			}
 			name, omitempty := fieldNameFromTags(srcType.Field(i), tags...)
-			if len(name) > 0 {
+			// The unexported fields can't be read by Interface and are not exported into the map
+			if len(name) > 0 && srcType.Field(i).IsExported() {
 				key, err := TryCast[K](name)
 				if err != nil {
 					return err
 				}
 				field := srcVal.Field(i)
-				fl := getValue(field.Interface())
-				if !omitempty || !IsEmpty(fl) {
+				fl, err := getCastValue(ctx, field.Interface(), valType)
+				if err != nil {
+					return wrapError(err, "`"+name+"` struct key")
+				}
+				if !omitempty || !isOmittedValue(field.Interface(), fl) {
 					if recursive {
 						dst[key], err = TryCastRecursiveContext[V](ctx, fl, tags...)
 					} else {
@@ -76,7 +90,7 @@ func TryMapCopyContext[K comparable, V any](ctx context.Context, dst map[K]V, sr
 					if err != nil {
 						return wrapError(err, "`"+name+"` struct key")
 					}
-				} // end if !omitempty || !IsEmpty(fl)
+				} // end if !omitempty || !isOmittedValue(...)
 			}
 		}
 	default:
@@ -85,6 +99,33 @@ func TryMapCopyContext[K comparable, V any](ctx context.Context, dst map[K]V, sr
 	return nil
 }
 
+// mapCopyGenerated puts the fields returned by the generated ToMap method into the map
+func mapCopyGenerated[K comparable, V any](ctx context.Context, dst map[K]V, fields map[string]any, valType reflect.Type, recursive bool, tags ...string) error {
+	i := 0
+	for name, val := range fields {
+		if err := contextError(ctx, i); err != nil {
+			return wrapError(err, "`"+name+"` struct key")
+		}
+		i++
+		key, err := TryCast[K](name)
+		if err != nil {
+			return err
+		}
+		fl, err := getCastValue(ctx, val, valType)
+		if err == nil {
+			if recursive {
+				dst[key], err = TryCastRecursiveContext[V](ctx, fl, tags...)
+			} else {
+				dst[key], err = TryCastContext[V](ctx, fl, tags...)
+			}
+		}
+		if err != nil {
+			return wrapError(err, "`"+name+"` struct key")
+		}
+	}
+	return nil
+}
+
 // ToMap cast your Source into the Destination type
 // tag defines the tags name in the structure to map the keys
 func ToMap(dst, src any, recursive bool, tags ...string) error {
@@ -105,8 +146,21 @@ func ToMapContext(ctx context.Context, dst, src any, recursive bool, tags ...str
 		err      error
 		destVal  = reflectTarget(reflect.ValueOf(dst))
 		destType = destVal.Type()
-		srcVal   = reflectTarget(reflect.ValueOf(src))
-		srcType  = srcVal.Type()
+	)
+
+	// Let the source define its own map representation
+	if res, ok, err := castGet(ctx, src, destType); err != nil {
+		return err
+	} else if ok {
+		if res == nil {
+			return nil
+		}
+		src = res
+	}
+
+	var (
+		srcVal  = reflectTarget(reflect.ValueOf(src))
+		srcType = srcVal.Type()
 	)
 
 	if dst = destVal.Interface(); dst == nil {
@@ -118,7 +172,10 @@ func ToMapContext(ctx context.Context, dst, src any, recursive bool, tags ...str
 	case map[any]any:
 		switch srcType.Kind() {
 		case reflect.Map:
-			for _, k := range srcVal.MapKeys() {
+			for i, k := range srcVal.MapKeys() {
+				if err := contextError(ctx, i); err != nil {
+					return wrapError(err, Str(k.Interface()))
+				}
 				field := srcVal.MapIndex(k)
 				if recursive {
 					dest[k.Interface()], err = mapDestValue(field.Interface(), destType, recursive, tags...)
@@ -131,11 +188,17 @@ func ToMapContext(ctx context.Context, dst, src any, recursive bool, tags ...str
 			}
 		case reflect.Struct:
 			for i := 0; i < srcVal.NumField(); i++ {
+				if err := contextError(ctx, i); err != nil {
+					return wrapError(err, srcType.Field(i).Name)
+				}
 				name, omitempty := fieldNameFromTags(srcType.Field(i), tags...)
-				if len(name) > 0 {
+				if len(name) > 0 && srcType.Field(i).IsExported() {
 					field := srcVal.Field(i)
-					fl := getValue(field.Interface())
-					if !omitempty || !IsEmpty(fl) {
+					fl, err := getCastValue(ctx, field.Interface(), destType.Elem())
+					if err != nil {
+						return wrapError(err, "`"+name+"` value")
+					}
+					if !omitempty || !isOmittedValue(field.Interface(), fl) {
 						if recursive {
 							dest[name], err = mapDestValue(fl, destType, recursive, tags...)
 							if err != nil {
@@ -144,7 +207,7 @@ func ToMapContext(ctx context.Context, dst, src any, recursive bool, tags ...str
 						} else {
 							dest[name] = fl
 						}
-					} // end if !omitempty || !IsEmpty(fl)
+					} // end if !omitempty || !isOmittedValue(...)
 				}
 			}
 		default:
@@ -161,7 +224,10 @@ func ToMapContext(ctx context.Context, dst, src any, recursive bool, tags ...str
 			elemType := destType.Elem()
 			switch srcType.Kind() {
 			case reflect.Map:
-				for _, k := range srcVal.MapKeys() {
+				for i, k := range srcVal.MapKeys() {
+					if err := contextError(ctx, i); err != nil {
+						return wrapError(err, Str(k.Interface()))
+					}
 					keyVal, err := ReflectTryToTypeContext(ctx, k, keyType, recursive, tags...)
 					if err != nil {
 						return wrapError(err, Str(k.Interface()))
@@ -175,11 +241,17 @@ func ToMapContext(ctx context.Context, dst, src any, recursive bool, tags ...str
 				}
 			case reflect.Struct:
 				for i := 0; i < srcVal.NumField(); i++ {
+					if err := contextError(ctx, i); err != nil {
+						return wrapError(err, srcType.Field(i).Name)
+					}
 					name, omitempty := fieldNameFromTags(srcType.Field(i), tags...)
-					if len(name) > 0 {
+					if len(name) > 0 && srcType.Field(i).IsExported() {
 						flVal := reflectTarget(srcVal.Field(i))
-						fl := getValue(flVal.Interface())
-						if !omitempty || !IsEmpty(fl) {
+						fl, err := getCastValue(ctx, flVal.Interface(), elemType)
+						if err != nil {
+							return wrapError(err, "`"+name+"` value")
+						}
+						if !omitempty || !isOmittedValue(flVal.Interface(), fl) {
 							keyVal, err := TryToType(name, keyType)
 							if err != nil {
 								return wrapError(err, name)
@@ -279,16 +351,16 @@ func IsMap(v any) bool {
 /// MARK: Helpers
 ///////////////////////////////////////////////////////////////////////////////
 
-func reflectMapValueByStringKeys(src reflect.Value, keys []string) any {
+func reflectMapValueByStringKeys(src reflect.Value, keys []string) (any, bool) {
 	mKeys := src.MapKeys()
 	for _, key := range keys {
 		for _, mKey := range mKeys {
 			if Str(mKey.Interface()) == key {
-				return src.MapIndex(mKey).Interface()
+				return src.MapIndex(mKey).Interface(), true
 			}
 		}
 	}
-	return nil
+	return nil, false
 }
 
 func mapDestValue(fl any, destType reflect.Type, recursive bool, tags ...string) (any, error) {
@@ -117,6 +117,33 @@ func TestMapDestValueSlice(t *testing.T) {
 	}
 }
 
+func TestMapUnexportedFields(t *testing.T) {
+	type item struct {
+		Name   string `json:"name"`
+		secret string
+	}
+	m, err := TryMap[string, any](item{Name: "name", secret: "secret"}, "json")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "name"}, m)
+
+	src := item{Name: "name", secret: "secret"}
+	anyMap := map[any]any{}
+	assert.NoError(t, ToMap(anyMap, src, false, "json"))
+	assert.Equal(t, map[any]any{"name": "name"}, anyMap)
+
+	strMap := map[string]string{}
+	assert.NoError(t, ToMap(strMap, src, false, "json"))
+	assert.Equal(t, map[string]string{"name": "name"}, strMap)
+
+	type counter struct {
+		Count int `json:"count"`
+		total int
+	}
+	intMap := map[string]int{}
+	assert.NoError(t, ToMap(intMap, counter{Count: 1, total: 2}, false, "json"))
+	assert.Equal(t, map[string]int{"count": 1}, intMap)
+}
+
 func TestIsMap(t *testing.T) {
 	tests := []struct {
 		src any
@@ -0,0 +1,198 @@
+package gocast
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// Mapping describes the reusable conversion of the Src value into the Dst struct.
+// Fields without rules are copied by names like TryCopyStructContext does.
+// The mapping must be configured before the first use, it's compiled once
+// and safe for the concurrent use after that.
+//
+//	var userMapping = gocast.NewMapping[UserDTO, User]("json").
+//	    Field("FullName", func(s UserDTO) any { return s.FirstName + " " + s.LastName }).
+//	    Rename("Email", "Mail").
+//	    Ignore("Password")
+//
+//	err := userMapping.Apply(ctx, &user, dto)
+type Mapping[Src, Dst any] struct {
+	tags    []string
+	fields  map[string]func(src Src) any
+	renames map[string]string // dst field name -> src field name
+	ignored map[string]bool
+
+	once    sync.Once
+	err     error
+	srcType reflect.Type
+	steps   []mappingStep[Src]
+}
+
+// mappingStep is the compiled rule of the destination field
+type mappingStep[Src any] struct {
+	ft     reflect.StructField
+	index  []int
+	fn     func(src Src) any
+	names  []string // Source field names or map keys
+	srcIdx []int    // Index of the source struct field, nil if not found
+}
+
+// NewMapping returns the new mapping, the tags are used to match
+// unconfigured fields like in TryCopyStructContext
+func NewMapping[Src, Dst any](tags ...string) *Mapping[Src, Dst] {
+	return &Mapping[Src, Dst]{
+		tags:    tags,
+		fields:  map[string]func(src Src) any{},
+		renames: map[string]string{},
+		ignored: map[string]bool{},
+	}
+}
+
+// Field sets the function returning the value of the destination field,
+// the value is converted into the field type
+func (m *Mapping[Src, Dst]) Field(dstName string, fn func(src Src) any) *Mapping[Src, Dst] {
+	m.fields[dstName] = fn
+	return m
+}
+
+// Rename copies the source field srcName into the destination field dstName
+func (m *Mapping[Src, Dst]) Rename(srcName, dstName string) *Mapping[Src, Dst] {
+	m.renames[dstName] = srcName
+	return m
+}
+
+// Ignore keeps the destination fields unchanged
+func (m *Mapping[Src, Dst]) Ignore(dstNames ...string) *Mapping[Src, Dst] {
+	for _, name := range dstNames {
+		m.ignored[name] = true
+	}
+	return m
+}
+
+// Compile prepares the mapping and checks the names of configured fields,
+// it's called automatically by the first Apply
+func (m *Mapping[Src, Dst]) Compile() error {
+	m.once.Do(func() { m.err = m.compile() })
+	return m.err
+}
+
+func (m *Mapping[Src, Dst]) compile() error {
+	var (
+		dstType = reflect.TypeOf((*Dst)(nil)).Elem()
+		srcType = reflect.TypeOf((*Src)(nil)).Elem()
+	)
+	for srcType.Kind() == reflect.Pointer {
+		srcType = srcType.Elem()
+	}
+	if dstType.Kind() != reflect.Struct {
+		return wrapError(ErrUnsupportedType, dstType.String())
+	}
+	if srcType.Kind() != reflect.Struct && srcType.Kind() != reflect.Map && srcType.Kind() != reflect.Interface {
+		return wrapError(ErrUnsupportedSourceType, srcType.String())
+	}
+	m.srcType = srcType
+
+	// Check the names of configured fields
+	for _, rules := range []map[string]bool{m.ignored, mapKeysSet(m.fields), mapKeysSet(m.renames)} {
+		for name := range rules {
+			if _, ok := dstType.FieldByName(name); !ok {
+				return wrapError(ErrStructFieldNameUndefined, name)
+			}
+		}
+	}
+
+	for _, ft := range ReflectStructFields(dstType) {
+		if !ft.IsExported() || m.ignored[ft.Name] {
+			continue
+		}
+		field, _ := dstType.FieldByName(ft.Name)
+		step := mappingStep[Src]{ft: ft, index: field.Index, fn: m.fields[ft.Name]}
+		if step.fn == nil {
+			if srcName, ok := m.renames[ft.Name]; ok {
+				step.names = []string{srcName}
+			} else if step.names = fieldNames(ft, m.tags...); len(step.names) < 1 {
+				continue
+			}
+			if srcType.Kind() == reflect.Struct {
+				for _, name := range step.names {
+					if sf, ok := srcType.FieldByName(name); ok && sf.IsExported() {
+						step.srcIdx = sf.Index
+						break
+					}
+				}
+				if _, renamed := m.renames[ft.Name]; renamed && step.srcIdx == nil {
+					return wrapError(ErrStructFieldNameUndefined, step.names[0])
+				}
+			}
+		}
+		m.steps = append(m.steps, step)
+	}
+	return nil
+}
+
+// Apply puts the values of the source into the destination by the mapping rules
+func (m *Mapping[Src, Dst]) Apply(ctx context.Context, dst *Dst, src Src) error {
+	if err := m.Compile(); err != nil {
+		return err
+	}
+	if dst == nil {
+		return wrapError(ErrInvalidParams, "Mapping.Apply `destination` parameter is nil")
+	}
+	srcVal := derefValue(reflect.ValueOf(src))
+	if !srcVal.IsValid() {
+		return wrapError(ErrInvalidParams, "Mapping.Apply `source` parameter is nil")
+	}
+	if srcVal.Kind() != reflect.Struct && srcVal.Kind() != reflect.Map {
+		return wrapError(ErrUnsupportedSourceType, srcVal.Type().String())
+	}
+
+	dstVal := reflect.ValueOf(dst).Elem()
+	for i := range m.steps {
+		step := &m.steps[i]
+		if err := contextError(ctx, i); err != nil {
+			return wrapError(err, step.ft.Name)
+		}
+		field, err := dstVal.FieldByIndexErr(step.index)
+		if err != nil || !field.CanSet() {
+			continue // Nil embedded pointer
+		}
+		var (
+			v     any
+			found bool
+		)
+		switch {
+		case step.fn != nil:
+			v, found = step.fn(src), true
+		case srcVal.Kind() == reflect.Map:
+			v, found = reflectMapValueByStringKeys(srcVal, step.names)
+		case srcVal.Type() != m.srcType:
+			// The fields of the interface source are resolved on each call
+			v, err = ReflectStructFieldValue(srcVal, step.names...)
+			found = err == nil
+		case step.srcIdx != nil:
+			if sf, err := srcVal.FieldByIndexErr(step.srcIdx); err == nil {
+				v, found = sf.Interface(), true
+			}
+		}
+		if err := setStructField(ctx, field, step.ft, v, found, m.tags...); err != nil {
+			return wrapError(err, step.ft.Name)
+		}
+	}
+	return nil
+}
+
+// Map returns the new Dst value converted from the source by the mapping rules
+func (m *Mapping[Src, Dst]) Map(ctx context.Context, src Src) (Dst, error) {
+	var dst Dst
+	err := m.Apply(ctx, &dst, src)
+	return dst, err
+}
+
+func mapKeysSet[V any](m map[string]V) map[string]bool {
+	keys := make(map[string]bool, len(m))
+	for key := range m {
+		keys[key] = true
+	}
+	return keys
+}
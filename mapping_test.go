@@ -0,0 +1,93 @@
+package gocast
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMappingDTO struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Age       string `json:"age"`
+	Password  string `json:"password"`
+	Status    string `json:"status"`
+}
+
+type testMappingModel struct {
+	FullName string         `json:"full_name"`
+	Mail     string         `json:"mail"`
+	Age      int            `json:"age"`
+	Password string         `json:"password"`
+	Status   testEnumStatus `json:"status"`
+	Note     string         `json:"note"`
+}
+
+func TestMapping(t *testing.T) {
+	ctx := context.TODO()
+	mapping := NewMapping[*testMappingDTO, testMappingModel]("json").
+		Field("FullName", func(s *testMappingDTO) any { return s.FirstName + " " + s.LastName }).
+		Rename("Email", "Mail").
+		Ignore("Password")
+	dto := &testMappingDTO{
+		FirstName: "Ada",
+		LastName:  "Lovelace",
+		Email:     "ada@example.com",
+		Age:       "36",
+		Password:  "secret",
+		Status:    "active",
+	}
+
+	t.Run("apply", func(t *testing.T) {
+		// Note is absent in the source and reset like in TryCopyStruct, Password is ignored
+		model := testMappingModel{Password: "hash", Note: "old"}
+		if !assert.NoError(t, mapping.Apply(ctx, &model, dto)) {
+			return
+		}
+		assert.Equal(t, testMappingModel{
+			FullName: "Ada Lovelace",
+			Mail:     "ada@example.com",
+			Age:      36,
+			Password: "hash",
+			Status:   testEnumStatusActive,
+		}, model)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		model, err := mapping.Map(ctx, &testMappingDTO{Age: "2", Status: "closed"})
+		assert.NoError(t, err)
+		assert.Equal(t, testEnumStatusClosed, model.Status)
+		assert.Equal(t, " ", model.FullName)
+	})
+
+	t.Run("map_source", func(t *testing.T) {
+		m := NewMapping[map[string]any, testMappingModel]("json").
+			Field("FullName", func(s map[string]any) any { return strings.ToUpper(Str(s["name"])) }).
+			Rename("email", "Mail")
+		model, err := m.Map(ctx, map[string]any{"name": "bob", "email": "bob@example.com", "age": 5.0})
+		assert.NoError(t, err)
+		assert.Equal(t, testMappingModel{FullName: "BOB", Mail: "bob@example.com", Age: 5}, model)
+	})
+
+	t.Run("interface_source", func(t *testing.T) {
+		m := NewMapping[any, testMappingModel]("json").Rename("Email", "Mail")
+		model, err := m.Map(ctx, dto)
+		assert.NoError(t, err)
+		assert.Equal(t, "ada@example.com", model.Mail)
+		assert.Equal(t, 36, model.Age)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		assert.ErrorIs(t, NewMapping[testMappingDTO, testMappingModel]().Ignore("Unknown").Compile(), ErrStructFieldNameUndefined)
+		assert.ErrorIs(t, NewMapping[testMappingDTO, testMappingModel]().Rename("Unknown", "Mail").Compile(), ErrStructFieldNameUndefined)
+		assert.ErrorIs(t, NewMapping[testMappingDTO, int]().Compile(), ErrUnsupportedType)
+
+		_, err := mapping.Map(ctx, &testMappingDTO{Age: "1", Status: "unknown"})
+		assert.ErrorIs(t, err, ErrInvalidEnumValue)
+		assert.ErrorIs(t, mapping.Apply(ctx, nil, dto), ErrInvalidParams)
+		assert.ErrorIs(t, mapping.Apply(ctx, &testMappingModel{}, nil), ErrInvalidParams)
+	})
+}
@@ -0,0 +1,232 @@
+package gocast
+
+import (
+	"reflect"
+)
+
+// MergeOptions defines how the source value is merged into the destination
+type MergeOptions struct {
+	CopyOptions
+
+	// ZeroOnly sets the source values only into empty destination values
+	ZeroOnly bool
+	// AppendSlices appends source slices to destination slices instead of replacing them
+	AppendSlices bool
+	// UnionMaps merges map keys recursively instead of replacing the whole map
+	UnionMaps bool
+	// Replace sets the source values even if they are empty
+	Replace bool
+
+	// replaced maps the destination pointers to their merged copies
+	replaced map[uintptr]reflect.Value
+}
+
+// TryMerge recursively overlays non-empty source values onto the destination.
+// Structs are merged field by field, assigned values are deep copied.
+// The values referenced by the destination pointers, maps and slices are not changed,
+// they are replaced by the merged copies, so the destination can share them with the defaults.
+// Fields with `copy:"-"` tag or CopyActionSkip from the FieldFilter are left unchanged.
+//
+//	cfg := defaultConfig
+//	err := gocast.TryMerge(&cfg, fileConfig, gocast.MergeOptions{UnionMaps: true})
+//	err = gocast.TryMerge(&cfg, flagConfig, gocast.MergeOptions{})
+func TryMerge[T any](dst *T, src T, opts MergeOptions) error {
+	if dst == nil {
+		return wrapError(ErrCopyInvalidValue, "nil destination")
+	}
+	visited := make(map[uintptr]reflect.Value)
+	opts.replaced = make(map[uintptr]reflect.Value)
+	return mergeValue(reflect.ValueOf(dst).Elem(), reflect.ValueOf(&src).Elem(), visited, opts, 0)
+}
+
+// Merge recursively overlays non-empty source values onto the destination
+// and panics on error
+func Merge[T any](dst *T, src T, opts MergeOptions) {
+	if err := TryMerge(dst, src, opts); err != nil {
+		panic(err)
+	}
+}
+
+func mergeValue(dst, src reflect.Value, visited map[uintptr]reflect.Value, opts MergeOptions, depth int) error {
+	if !src.IsValid() || !dst.CanSet() || (opts.MaxDepth > 0 && depth >= opts.MaxDepth) {
+		return nil
+	}
+	switch copyPolicyOf(src.Type()) {
+	case CopyPolicyZero:
+		return nil // Keep the state of the destination value
+	case CopyPolicyShare:
+		return mergeLeaf(dst, src, visited, opts, depth)
+	}
+
+	switch src.Kind() {
+	case reflect.Struct:
+		if isMergeableStruct(src.Type()) {
+			return mergeStruct(dst, src, visited, opts, depth)
+		}
+	case reflect.Pointer:
+		if !src.IsNil() && !dst.IsNil() {
+			// The destination value can be shared with other values (defaults),
+			// it's merged into the copy which replaces the pointer on success
+			newPtr, copied := opts.replaced[dst.Pointer()]
+			if _, ok := visited[src.Pointer()]; ok {
+				if copied {
+					dst.Set(newPtr)
+				}
+				return nil
+			}
+			if !copied {
+				newPtr = reflect.New(dst.Type().Elem())
+				newPtr.Elem().Set(dst.Elem())
+				opts.replaced[dst.Pointer()] = newPtr
+			}
+			visited[src.Pointer()] = newPtr
+			if err := mergeValue(newPtr.Elem(), src.Elem(), visited, opts, depth+1); err != nil {
+				return err
+			}
+			dst.Set(newPtr)
+			return nil
+		}
+	case reflect.Interface:
+		if !src.IsNil() && !dst.IsNil() && src.Elem().Type() == dst.Elem().Type() {
+			newDst := reflect.New(dst.Elem().Type()).Elem()
+			newDst.Set(dst.Elem())
+			if err := mergeValue(newDst, src.Elem(), visited, opts, depth+1); err != nil {
+				return err
+			}
+			dst.Set(newDst)
+			return nil
+		}
+	case reflect.Slice:
+		if opts.AppendSlices && !src.IsNil() && !dst.IsNil() {
+			return mergeSliceAppend(dst, src, visited, opts, depth)
+		}
+	case reflect.Map:
+		if opts.UnionMaps && !src.IsNil() && !dst.IsNil() {
+			return mergeMapUnion(dst, src, visited, opts, depth)
+		}
+	}
+	return mergeLeaf(dst, src, visited, opts, depth)
+}
+
+// mergeLeaf replaces the destination value by the copy of the source value
+func mergeLeaf(dst, src reflect.Value, visited map[uintptr]reflect.Value, opts MergeOptions, depth int) error {
+	if !opts.Replace && isEmptyMergeValue(src) {
+		return nil
+	}
+	if opts.ZeroOnly && !isEmptyMergeValue(dst) {
+		return nil
+	}
+	dst.Set(reflect.Zero(dst.Type()))
+	return deepCopyWithOptions(src, dst, visited, opts.CopyOptions, depth)
+}
+
+func mergeStruct(dst, src reflect.Value, visited map[uintptr]reflect.Value, opts MergeOptions, depth int) error {
+	src = addressableValue(src)
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Type().Field(i)
+		if opts.IgnoreUnexportedFields && !field.IsExported() {
+			continue
+		}
+
+		dstField := accessibleField(dst, i)
+		if !dstField.CanSet() {
+			continue
+		}
+
+		var (
+			err       error
+			srcField  = accessibleField(src, i)
+			fieldOpts = opts
+		)
+		fieldOpts.CopyOptions = opts.withField(field.Name)
+		switch opts.fieldCopyAction(field) {
+		case CopyActionSkip:
+		case CopyActionShallow:
+			if (opts.Replace || !isEmptyMergeValue(srcField)) &&
+				(!opts.ZeroOnly || isEmptyMergeValue(dstField)) {
+				dstField.Set(srcField)
+			}
+		default:
+			err = mergeValue(dstField, srcField, visited, fieldOpts, depth+1)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mergeSliceAppend(dst, src reflect.Value, visited map[uintptr]reflect.Value, opts MergeOptions, depth int) error {
+	items := reflect.New(src.Type()).Elem()
+	if err := deepCopyWithOptions(src, items, visited, opts.CopyOptions, depth); err != nil {
+		return err
+	}
+	// The new slice is allocated to keep the backing array of the destination unchanged
+	res := reflect.MakeSlice(dst.Type(), 0, dst.Len()+items.Len())
+	dst.Set(reflect.AppendSlice(reflect.AppendSlice(res, dst), items))
+	return nil
+}
+
+func mergeMapUnion(dst, src reflect.Value, visited map[uintptr]reflect.Value, opts MergeOptions, depth int) error {
+	// The keys are set into the copy of the map to keep the destination map unchanged
+	// if it's shared with other values
+	res := reflect.MakeMapWithSize(dst.Type(), dst.Len())
+	for iter := dst.MapRange(); iter.Next(); {
+		res.SetMapIndex(iter.Key(), iter.Value())
+	}
+	if err := mergeMapItems(res, src, visited, opts, depth); err != nil {
+		return err
+	}
+	dst.Set(res)
+	return nil
+}
+
+func mergeMapItems(dst, src reflect.Value, visited map[uintptr]reflect.Value, opts MergeOptions, depth int) error {
+	valType := dst.Type().Elem()
+	iter := src.MapRange()
+	for iter.Next() {
+		key := iter.Key()
+		newVal := reflect.New(valType).Elem()
+		if dstVal := dst.MapIndex(key); dstVal.IsValid() {
+			newVal.Set(dstVal)
+			if err := mergeValue(newVal, iter.Value(), visited, opts, depth+1); err != nil {
+				return err
+			}
+		} else if err := deepCopyWithOptions(iter.Value(), newVal, visited, opts.CopyOptions, depth+1); err != nil {
+			return err
+		}
+		dstKey := reflect.New(key.Type()).Elem()
+		if err := deepCopyWithOptions(key, dstKey, visited, opts.CopyOptions, depth+1); err != nil {
+			return err
+		}
+		dst.SetMapIndex(dstKey, newVal)
+	}
+	return nil
+}
+
+// isEmptyMergeValue returns true if the value is empty like by IsEmptyByReflection
+// or it's the zero struct or array (time.Time{}) which are not empty for IsEmptyByReflection
+func isEmptyMergeValue(v reflect.Value) bool {
+	if IsEmptyByReflection(v) {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Struct, reflect.Array:
+		return v.IsZero()
+	}
+	return false
+}
+
+// isMergeableStruct returns true if the struct can be merged field by field.
+// Structs without exported fields (time.Time, Optional, etc.) are merged as a whole value.
+func isMergeableStruct(t reflect.Type) bool {
+	if isSQLNullType(t) {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package gocast
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMergeServer struct {
+	Host    string
+	Port    int
+	Timeout time.Duration
+}
+
+type testMergeConfig struct {
+	mu       *sync.Mutex
+	Name     string
+	Debug    bool
+	Server   *testMergeServer
+	Tags     []string
+	Labels   map[string]string
+	Extra    map[string]any
+	Started  time.Time
+	Internal string `copy:"-"`
+}
+
+func TestTryCopyInto(t *testing.T) {
+	src := testMergeConfig{Name: "app", Server: &testMergeServer{Host: "localhost"}, Tags: []string{"a"}}
+	dst := testMergeConfig{Debug: true, Labels: map[string]string{"a": "b"}}
+	assert.NoError(t, TryCopyInto(&dst, src, CopyOptions{}))
+	assert.Equal(t, "app", dst.Name)
+	assert.False(t, dst.Debug)
+	assert.Nil(t, dst.Labels)
+	assert.Equal(t, src.Server, dst.Server)
+	assert.NotSame(t, src.Server, dst.Server)
+	assert.NotSame(t, &src.Tags[0], &dst.Tags[0])
+
+	assert.ErrorIs(t, TryCopyInto(nil, src, CopyOptions{}), ErrCopyInvalidValue)
+	assert.Panics(t, func() { CopyInto(nil, src, CopyOptions{}) })
+
+	// The destination is unchanged on error
+	type handler struct {
+		Name string
+		Fn   func()
+	}
+	hdst := handler{Name: "old"}
+	err := TryCopyInto(&hdst, handler{Name: "new", Fn: func() {}}, CopyOptions{})
+	assert.ErrorIs(t, err, ErrCopyUnsupportedType)
+	assert.Equal(t, "old", hdst.Name)
+}
+
+func TestMerge(t *testing.T) {
+	newDefaults := func() testMergeConfig {
+		return testMergeConfig{
+			Name:     "app",
+			Server:   &testMergeServer{Host: "localhost", Port: 80, Timeout: time.Second},
+			Tags:     []string{"default"},
+			Labels:   map[string]string{"env": "dev", "team": "core"},
+			Extra:    map[string]any{"db": map[string]any{"host": "localhost", "port": 5432}},
+			Internal: "keep",
+		}
+	}
+	started := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	file := testMergeConfig{
+		Server:   &testMergeServer{Port: 8080},
+		Tags:     []string{"file"},
+		Labels:   map[string]string{"env": "prod"},
+		Extra:    map[string]any{"db": map[string]any{"host": "db"}},
+		Started:  started,
+		Internal: "file",
+	}
+
+	t.Run("overwrite", func(t *testing.T) {
+		cfg := newDefaults()
+		assert.NoError(t, TryMerge(&cfg, file, MergeOptions{}))
+		assert.Equal(t, "app", cfg.Name)
+		assert.Equal(t, testMergeServer{Host: "localhost", Port: 8080, Timeout: time.Second}, *cfg.Server)
+		assert.Equal(t, []string{"file"}, cfg.Tags)
+		assert.Equal(t, map[string]string{"env": "prod"}, cfg.Labels)
+		assert.Equal(t, started, cfg.Started)
+		assert.Equal(t, "keep", cfg.Internal)
+		assert.NotSame(t, &file.Tags[0], &cfg.Tags[0])
+	})
+
+	t.Run("shared_defaults", func(t *testing.T) {
+		defaults := newDefaults()
+		cfg := defaults
+		assert.NoError(t, TryMerge(&cfg, file, MergeOptions{AppendSlices: true, UnionMaps: true}))
+		assert.Equal(t, 8080, cfg.Server.Port)
+		assert.Equal(t, []string{"default", "file"}, cfg.Tags)
+		assert.Equal(t, "prod", cfg.Labels["env"])
+
+		// The values shared with the defaults are replaced, not changed
+		assert.Equal(t, newDefaults(), defaults)
+		assert.NotSame(t, defaults.Server, cfg.Server)
+	})
+
+	t.Run("zero_struct", func(t *testing.T) {
+		cfg := newDefaults()
+		cfg.Started = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		src := file
+		src.Started = time.Time{}
+		assert.NoError(t, TryMerge(&cfg, src, MergeOptions{}))
+		assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), cfg.Started)
+
+		assert.NoError(t, TryMerge(&cfg, src, MergeOptions{Replace: true}))
+		assert.True(t, cfg.Started.IsZero())
+	})
+
+	t.Run("append_union", func(t *testing.T) {
+		cfg := newDefaults()
+		assert.NoError(t, TryMerge(&cfg, file, MergeOptions{AppendSlices: true, UnionMaps: true}))
+		assert.Equal(t, []string{"default", "file"}, cfg.Tags)
+		assert.Equal(t, map[string]string{"env": "prod", "team": "core"}, cfg.Labels)
+		assert.Equal(t, map[string]any{"db": map[string]any{"host": "db", "port": 5432}}, cfg.Extra)
+	})
+
+	t.Run("zero_only", func(t *testing.T) {
+		cfg := testMergeConfig{Name: "custom", Server: &testMergeServer{Port: 9000}}
+		assert.NoError(t, TryMerge(&cfg, newDefaults(), MergeOptions{ZeroOnly: true}))
+		assert.Equal(t, "custom", cfg.Name)
+		assert.Equal(t, testMergeServer{Host: "localhost", Port: 9000, Timeout: time.Second}, *cfg.Server)
+		assert.Equal(t, []string{"default"}, cfg.Tags)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		cfg := newDefaults()
+		assert.NoError(t, TryMerge(&cfg, testMergeConfig{Debug: true}, MergeOptions{Replace: true}))
+		assert.Equal(t, testMergeConfig{Debug: true, Internal: "keep"}, cfg)
+	})
+
+	t.Run("field_filter", func(t *testing.T) {
+		cfg := newDefaults()
+		err := TryMerge(&cfg, file, MergeOptions{CopyOptions: CopyOptions{
+			FieldFilter: func(path []string, f reflect.StructField) CopyAction {
+				if f.Name == "Port" && len(path) == 1 && path[0] == "Server" {
+					return CopyActionSkip
+				}
+				return CopyActionDefault
+			},
+		}})
+		assert.NoError(t, err)
+		assert.Equal(t, 80, cfg.Server.Port)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		type node struct {
+			Value int
+			Next  *node
+		}
+		dst := &node{Value: 1}
+		dst.Next = dst
+		src := &node{Value: 2}
+		src.Next = src
+		assert.NoError(t, TryMerge(&dst, src, MergeOptions{}))
+		assert.Equal(t, 2, dst.Value)
+		assert.Same(t, dst, dst.Next)
+	})
+
+	t.Run("locked_destination", func(t *testing.T) {
+		cfg := newDefaults()
+		cfg.mu = &sync.Mutex{}
+		cfg.mu.Lock()
+		src := file
+		src.mu = &sync.Mutex{}
+		assert.NoError(t, TryMerge(&cfg, src, MergeOptions{}))
+		assert.False(t, cfg.mu.TryLock(), "destination mutex must keep its state")
+		cfg.mu.Unlock()
+	})
+
+	t.Run("nil_destination", func(t *testing.T) {
+		assert.ErrorIs(t, TryMerge(nil, file, MergeOptions{}), ErrCopyInvalidValue)
+		assert.Panics(t, func() { Merge(nil, file, MergeOptions{}) })
+	})
+}
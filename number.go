@@ -1,6 +1,8 @@
 package gocast
 
 import (
+	"context"
+	"reflect"
 	"strconv"
 	"strings"
 )
@@ -71,6 +73,31 @@ func TryNumber[R Numeric](v any) (R, error) {
 	case float64:
 		return R(v), nil
 	}
+	if nv, ok := unwrapSQLNull(v); ok {
+		return TryNumber[R](nv)
+	}
+	if res, ok, err := castGet(context.Background(), v, reflect.TypeOf(R(0))); err != nil {
+		return R(0), err
+	} else if ok {
+		return TryNumber[R](res)
+	}
+	// Registered enum types are converted by the kind of the type
+	rv := reflect.ValueOf(v)
+	if enumOf(rv.Type()) == nil {
+		return R(0), ErrUnsupportedNumericType
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return R(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return R(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return R(rv.Float()), nil
+	case reflect.Bool:
+		return TryNumber[R](rv.Bool())
+	case reflect.String:
+		return TryNumber[R](rv.String())
+	}
 	return R(0), ErrUnsupportedNumericType
 }
 
@@ -0,0 +1,157 @@
+package gocast
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+)
+
+// optionalValue is implemented by Optional of any type
+type optionalValue interface {
+	IsSet() bool
+	IsNull() bool
+}
+
+// optionalSetter is implemented by the pointer to Optional of any type
+type optionalSetter interface {
+	optionalValue
+	SetNull()
+}
+
+// Optional value distinguishes the absent value from the zero or null one.
+// The zero Optional is unset.
+type Optional[T any] struct {
+	value T
+	set   bool
+	null  bool
+}
+
+// NewOptional returns the Optional with the value
+func NewOptional[T any](v T) Optional[T] {
+	return Optional[T]{value: v, set: true}
+}
+
+// Set puts the value and marks the Optional as set
+func (o *Optional[T]) Set(v T) {
+	o.value, o.set, o.null = v, true, false
+}
+
+// SetNull marks the Optional as explicitly set to null
+func (o *Optional[T]) SetNull() {
+	var zero T
+	o.value, o.set, o.null = zero, true, true
+}
+
+// Unset resets the Optional to the absent state
+func (o *Optional[T]) Unset() {
+	*o = Optional[T]{}
+}
+
+// Get returns the value and true if the value is set and not null
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.set && !o.null
+}
+
+// OrElse returns the value if it is set and not null, else def
+func (o Optional[T]) OrElse(def T) T {
+	if o.set && !o.null {
+		return o.value
+	}
+	return def
+}
+
+// IsSet returns true if the value was set, including the null value
+func (o Optional[T]) IsSet() bool { return o.set }
+
+// IsNull returns true if the value was explicitly set to null
+func (o Optional[T]) IsNull() bool { return o.set && o.null }
+
+// IsZero returns true if the value is absent, so `json:",omitzero"` skips it
+func (o Optional[T]) IsZero() bool { return !o.set }
+
+// CastSet converts the value into the Optional, nil marks it as null
+func (o *Optional[T]) CastSet(ctx context.Context, v any) error {
+	switch val := v.(type) {
+	case nil:
+		o.SetNull()
+		return nil
+	case Optional[T]:
+		*o = val
+		return nil
+	case *Optional[T]:
+		if val == nil {
+			o.SetNull()
+		} else {
+			*o = *val
+		}
+		return nil
+	}
+	if IsNil(v) {
+		o.SetNull()
+		return nil
+	}
+	val, err := TryCastContext[T](ctx, v)
+	if err != nil {
+		return err
+	}
+	o.Set(val)
+	return nil
+}
+
+// CastGet returns the value of the Optional, absent and null values are converted as zero
+func (o Optional[T]) CastGet(ctx context.Context, t reflect.Type) (any, error) {
+	if !o.set || o.null {
+		return reflect.Zero(t).Interface(), nil
+	}
+	return o.value, nil
+}
+
+// Value implements driver.Valuer, absent and null values are stored as NULL
+func (o Optional[T]) Value() (driver.Value, error) {
+	if !o.set || o.null {
+		return nil, nil
+	}
+	if vl, ok := any(o.value).(driver.Valuer); ok {
+		return vl.Value()
+	}
+	return o.value, nil
+}
+
+// Scan implements sql.Scanner
+func (o *Optional[T]) Scan(src any) error {
+	return o.CastSet(context.Background(), src)
+}
+
+// MarshalJSON implements json.Marshaler, absent and null values are encoded as null
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.set || o.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		o.SetNull()
+		return nil
+	}
+	var val T
+	if err := json.Unmarshal(data, &val); err != nil {
+		return err
+	}
+	o.Set(val)
+	return nil
+}
+
+// isOmittedValue returns true if the field value must be skipped by omitempty
+func isOmittedValue(raw, val any) bool {
+	if opt, ok := raw.(optionalValue); ok {
+		return !opt.IsSet()
+	}
+	if _, ok := unwrapSQLNull(raw); ok {
+		return IsEmpty(raw)
+	}
+	return IsEmpty(val)
+}
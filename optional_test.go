@@ -0,0 +1,127 @@
+package gocast
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptional(t *testing.T) {
+	t.Run("state", func(t *testing.T) {
+		var opt Optional[int]
+		assert.False(t, opt.IsSet())
+		assert.False(t, opt.IsNull())
+		assert.Equal(t, 5, opt.OrElse(5))
+
+		opt.Set(0)
+		v, ok := opt.Get()
+		assert.True(t, ok)
+		assert.Equal(t, 0, v)
+		assert.Equal(t, 0, opt.OrElse(5))
+
+		opt.SetNull()
+		_, ok = opt.Get()
+		assert.False(t, ok)
+		assert.True(t, opt.IsSet())
+		assert.True(t, opt.IsNull())
+
+		opt.Unset()
+		assert.False(t, opt.IsSet())
+		assert.Equal(t, 3, NewOptional(3).OrElse(1))
+	})
+
+	t.Run("cast", func(t *testing.T) {
+		assert.Equal(t, NewOptional(10), Cast[Optional[int]]("10"))
+		assert.Equal(t, 10, Cast[int](NewOptional("10")))
+		assert.Equal(t, "10", Str(NewOptional(10)))
+		assert.Equal(t, 0, Number[int](Optional[int]{}))
+
+		var opt Optional[int]
+		assert.Error(t, opt.CastSet(context.Background(), "abc"))
+		assert.False(t, opt.IsSet())
+		assert.NoError(t, opt.CastSet(context.Background(), nil))
+		assert.True(t, opt.IsNull())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.True(t, IsEmpty(Optional[int]{}))
+		assert.False(t, IsEmpty(NewOptional(0)))
+		assert.True(t, IsEmpty(&Optional[string]{}))
+		var null Optional[string]
+		null.SetNull()
+		assert.False(t, IsEmpty(null))
+		assert.False(t, IsEmptyByReflection(reflect.ValueOf(NewOptional(""))))
+		assert.True(t, IsEmptyByReflection(reflect.ValueOf(&Optional[bool]{})))
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		var opt Optional[int64]
+		assert.NoError(t, opt.Scan([]byte("12")))
+		assert.Equal(t, NewOptional[int64](12), opt)
+		val, err := opt.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), val)
+
+		assert.NoError(t, opt.Scan(nil))
+		assert.True(t, opt.IsNull())
+		val, err = opt.Value()
+		assert.NoError(t, err)
+		assert.Nil(t, val)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		type item struct {
+			A Optional[int]    `json:"a"`
+			B Optional[string] `json:"b"`
+			C Optional[int]    `json:"c"`
+		}
+		var it item
+		assert.NoError(t, json.Unmarshal([]byte(`{"a":1,"b":null}`), &it))
+		assert.Equal(t, NewOptional(1), it.A)
+		assert.True(t, it.B.IsNull())
+		assert.False(t, it.C.IsSet())
+
+		data, err := json.Marshal(it)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"a":1,"b":null,"c":null}`, string(data))
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type target struct {
+			Name  Optional[string] `json:"name"`
+			Age   Optional[int]    `json:"age"`
+			Email Optional[string] `json:"email"`
+		}
+		var res target
+		err := TryCopyStruct(&res, map[string]any{"name": "Bob", "age": nil}, "json")
+		assert.NoError(t, err)
+		assert.Equal(t, NewOptional("Bob"), res.Name)
+		assert.True(t, res.Age.IsNull())
+		assert.False(t, res.Email.IsSet())
+
+		var res2 target
+		assert.NoError(t, TryCopyStruct(&res2, res))
+		assert.Equal(t, res, res2)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		type source struct {
+			Name  Optional[string] `json:"name,omitempty"`
+			Age   Optional[int]    `json:"age,omitempty"`
+			Email Optional[string] `json:"email,omitempty"`
+			Zero  Optional[int]    `json:"zero,omitempty"`
+		}
+		src := source{Name: NewOptional("Bob"), Zero: NewOptional(0)}
+		src.Age.SetNull()
+		m, err := TryMap[string, any](src, "json")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"name": "Bob", "age": nil, "zero": 0}, m)
+
+		ms, err := TryMap[string, string](src, "json")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"name": "Bob", "age": "", "zero": "0"}, ms)
+	})
+}
@@ -0,0 +1,504 @@
+package gocast
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PatchOperation is the operation of RFC 6902 JSON Patch
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	From  string `json:"from,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// ApplyPatch applies RFC 7396 JSON Merge Patch to the struct or map.
+// Absent keys leave the values unchanged, null values reset fields to zero
+// and delete map keys, nested objects are merged recursively.
+// Values are converted into the field types. The patch is applied to the deep copy
+// of the destination, which replaces it on success, so on error the destination is unchanged.
+//
+//	err := gocast.ApplyPatch(ctx, &user, map[string]any{"name": "Bob", "email": nil}, "json")
+func ApplyPatch(ctx context.Context, dst any, patch map[string]any, tags ...string) error {
+	target, err := patchTarget(dst)
+	if err != nil {
+		return err
+	}
+	work, err := patchCopy(target)
+	if err != nil {
+		return err
+	}
+	if err = mergePatch(ctx, work, patch, tags); err != nil {
+		return err
+	}
+	target.Set(work)
+	return nil
+}
+
+// ApplyJSONPatch applies RFC 6902 JSON Patch operations (add, remove, replace, move, copy, test)
+// to the struct, map or slice. Paths are JSON pointers like "/items/0/name",
+// fields are resolved by the names from tags. All operations are applied
+// to the deep copy of the destination, which replaces it only if all of them succeed.
+// The "test" operation compares values by EqualCast, so the JSON value "1"
+// matches the numeric field 1 like the other operations convert values into the field types.
+//
+//	err := gocast.ApplyJSONPatch(ctx, &order, []gocast.PatchOperation{
+//		{Op: "replace", Path: "/status", Value: "paid"},
+//		{Op: "add", Path: "/tags/-", Value: "vip"},
+//	}, "json")
+func ApplyJSONPatch(ctx context.Context, dst any, ops []PatchOperation, tags ...string) error {
+	target, err := patchTarget(dst)
+	if err != nil {
+		return err
+	}
+	work, err := patchCopy(target)
+	if err != nil {
+		return err
+	}
+	for _, op := range ops {
+		if err = applyPatchOperation(ctx, work, op, tags); err != nil {
+			return wrapError(err, op.Op+" "+op.Path)
+		}
+	}
+	target.Set(work)
+	return nil
+}
+
+// patchTarget returns the settable value of the destination pointer
+func patchTarget(dst any) (reflect.Value, error) {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return reflect.Value{}, wrapError(ErrInvalidParams, "patch destination must be a non-nil pointer")
+	}
+	return v.Elem(), nil
+}
+
+// patchCopy returns the deep copy of the value to apply the patch.
+// The copy tags are ignored to keep all values of the destination. The channels,
+// functions and the values of the types with the copy policy (locks, files) are kept
+// as is, they are put back into the destination together with the patched value.
+func patchCopy(target reflect.Value) (reflect.Value, error) {
+	opts := CopyOptions{FieldFilter: func(_ []string, f reflect.StructField) CopyAction {
+		if k := f.Type.Kind(); k == reflect.Chan || k == reflect.Func || copyPolicyOf(f.Type) != CopyPolicyDeep {
+			return CopyActionShallow
+		}
+		return CopyActionDeep
+	}}
+	work := reflect.New(target.Type()).Elem()
+	if err := deepCopyWithOptions(target, work, make(map[uintptr]reflect.Value), opts, 0); err != nil {
+		return reflect.Value{}, wrapError(err, "patch destination copy")
+	}
+	return work, nil
+}
+
+func mergePatch(ctx context.Context, target reflect.Value, patch any, tags []string) error {
+	obj, ok := patch.(map[string]any)
+	if !ok {
+		return setPatchValue(ctx, target, patch, tags)
+	}
+
+	switch target.Kind() {
+	case reflect.Pointer:
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return mergePatch(ctx, target.Elem(), patch, tags)
+	case reflect.Interface:
+		cur := target.Elem()
+		if !cur.IsValid() || derefValue(cur).Kind() != reflect.Map && derefValue(cur).Kind() != reflect.Struct {
+			// Not an object target is replaced by the patch without null members
+			return setPatchValue(ctx, target, removePatchNulls(obj), tags)
+		}
+		val := reflect.New(cur.Type()).Elem()
+		val.Set(cur)
+		if err := mergePatch(ctx, val, patch, tags); err != nil {
+			return err
+		}
+		target.Set(val)
+	case reflect.Map:
+		if target.IsNil() {
+			target.Set(reflect.MakeMap(target.Type()))
+		}
+		for key, val := range obj {
+			mapKey, err := transformTo(ctx, key, target.Type().Key(), tags...)
+			if err != nil {
+				return wrapError(err, key)
+			}
+			if val == nil {
+				target.SetMapIndex(mapKey, reflect.Value{})
+				continue
+			}
+			item := reflect.New(target.Type().Elem()).Elem()
+			if cur := target.MapIndex(mapKey); cur.IsValid() {
+				item.Set(cur)
+			}
+			if err := mergePatch(ctx, item, val, tags); err != nil {
+				return wrapError(err, key)
+			}
+			target.SetMapIndex(mapKey, item)
+		}
+	case reflect.Struct:
+		if !isMergeableStruct(target.Type()) {
+			return setPatchValue(ctx, target, patch, tags)
+		}
+		for key, val := range obj {
+			field, ok := patchStructField(target, key, tags)
+			if !ok {
+				continue // Unknown keys are ignored like in JSON decoding
+			}
+			if err := mergePatch(ctx, field, val, tags); err != nil {
+				return wrapError(err, key)
+			}
+		}
+	default:
+		return setPatchValue(ctx, target, patch, tags)
+	}
+	return nil
+}
+
+// removePatchNulls returns the copy of the object without null members
+func removePatchNulls(obj map[string]any) map[string]any {
+	res := make(map[string]any, len(obj))
+	for key, val := range obj {
+		switch v := val.(type) {
+		case nil:
+		case map[string]any:
+			res[key] = removePatchNulls(v)
+		default:
+			res[key] = v
+		}
+	}
+	return res
+}
+
+// setPatchValue puts the converted value, nil resets the value to zero
+func setPatchValue(ctx context.Context, target reflect.Value, value any, tags []string) error {
+	if value == nil {
+		if target.CanAddr() {
+			if opt, _ := target.Addr().Interface().(optionalSetter); opt != nil {
+				opt.SetNull() // Explicit null differs from the absent value
+				return nil
+			}
+		}
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+	val, err := transformTo(ctx, value, target.Type(), tags...)
+	if err != nil {
+		return err
+	}
+	target.Set(val)
+	return nil
+}
+
+// patchStructField returns the struct field by the name from tags
+func patchStructField(v reflect.Value, name string, tags []string) (reflect.Value, bool) {
+	for _, ft := range ReflectStructFields(v.Type()) {
+		if ft.IsExported() && stringsContain(fieldNames(ft, tags...), name) {
+			if field := v.FieldByName(ft.Name); field.CanSet() {
+				return field, true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// MARK: JSON Patch
+///////////////////////////////////////////////////////////////////////////////
+
+func applyPatchOperation(ctx context.Context, target reflect.Value, op PatchOperation, tags []string) error {
+	path, err := parseJSONPointer(op.Path)
+	if err != nil {
+		return err
+	}
+	switch op.Op {
+	case "add":
+		return patchAdd(ctx, target, path, op.Value, tags)
+	case "remove":
+		if len(path) == 0 {
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		}
+		return patchUpdate(ctx, target, path, tags, func(c reflect.Value, key string) error {
+			return patchRemoveAt(ctx, c, key, tags)
+		})
+	case "replace":
+		if len(path) == 0 {
+			return setPatchValue(ctx, target, op.Value, tags)
+		}
+		return patchUpdate(ctx, target, path, tags, func(c reflect.Value, key string) error {
+			return patchReplaceAt(ctx, c, key, op.Value, tags)
+		})
+	case "move", "copy":
+		from, err := parseJSONPointer(op.From)
+		if err != nil {
+			return err
+		}
+		if op.Op == "move" && len(path) > len(from) && stringsEqual(path[:len(from)], from) {
+			return wrapError(ErrInvalidPatch, "can't move the value into its child")
+		}
+		val, err := patchGet(ctx, target, from, tags)
+		if err != nil {
+			return err
+		}
+		value, err := CopyInterface(val.Interface())
+		if err != nil {
+			return err
+		}
+		if op.Op == "move" {
+			if err := applyPatchOperation(ctx, target, PatchOperation{Op: "remove", Path: op.From}, tags); err != nil {
+				return err
+			}
+		}
+		return patchAdd(ctx, target, path, value, tags)
+	case "test":
+		val, err := patchGet(ctx, target, path, tags)
+		if err != nil {
+			return err
+		}
+		if !EqualCast(val.Interface(), op.Value, WithEqualTags(tags...)) {
+			return wrapError(ErrPatchTestFailed, op.Path)
+		}
+		return nil
+	}
+	return wrapError(ErrInvalidPatch, "unsupported operation "+strconv.Quote(op.Op))
+}
+
+// parseJSONPointer splits RFC 6901 JSON Pointer into the list of unescaped tokens
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, wrapError(ErrInvalidPatch, "invalid path "+strconv.Quote(pointer))
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func patchAdd(ctx context.Context, target reflect.Value, path []string, value any, tags []string) error {
+	if len(path) == 0 {
+		return setPatchValue(ctx, target, value, tags)
+	}
+	return patchUpdate(ctx, target, path, tags, func(c reflect.Value, key string) error {
+		return patchAddAt(ctx, c, key, value, tags)
+	})
+}
+
+// patchGet returns the value by the path
+func patchGet(ctx context.Context, v reflect.Value, path []string, tags []string) (reflect.Value, error) {
+	for _, key := range path {
+		v = derefValue(v)
+		if !v.IsValid() {
+			return v, wrapError(ErrPatchPathNotFound, key)
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			field, ok := patchStructField(v, key, tags)
+			if !ok {
+				return v, wrapError(ErrPatchPathNotFound, key)
+			}
+			v = field
+		case reflect.Slice, reflect.Array:
+			idx, err := patchIndex(key, v.Len()-1)
+			if err != nil {
+				return v, err
+			}
+			v = v.Index(idx)
+		case reflect.Map:
+			mapKey, err := transformTo(ctx, key, v.Type().Key(), tags...)
+			if err != nil {
+				return v, err
+			}
+			if v = v.MapIndex(mapKey); !v.IsValid() {
+				return v, wrapError(ErrPatchPathNotFound, key)
+			}
+		default:
+			return v, wrapError(ErrPatchPathNotFound, key)
+		}
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return v, wrapError(ErrPatchPathNotFound, strings.Join(path, "/"))
+	}
+	return v, nil
+}
+
+// patchUpdate finds the container of the last path token and calls fn for it.
+// Map items and interface values are not addressable, so they are changed
+// in the copy which is put back after the update.
+func patchUpdate(ctx context.Context, v reflect.Value, path []string, tags []string, fn func(c reflect.Value, key string) error) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return wrapError(ErrPatchPathNotFound, path[0])
+		}
+		return patchUpdate(ctx, v.Elem(), path, tags, fn)
+	case reflect.Interface:
+		if v.IsNil() {
+			return wrapError(ErrPatchPathNotFound, path[0])
+		}
+		val := reflect.New(v.Elem().Type()).Elem()
+		val.Set(v.Elem())
+		if err := patchUpdate(ctx, val, path, tags, fn); err != nil {
+			return err
+		}
+		v.Set(val)
+		return nil
+	}
+
+	key := path[0]
+	if len(path) == 1 {
+		return fn(v, key)
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		field, ok := patchStructField(v, key, tags)
+		if !ok {
+			return wrapError(ErrPatchPathNotFound, key)
+		}
+		return patchUpdate(ctx, field, path[1:], tags, fn)
+	case reflect.Slice, reflect.Array:
+		idx, err := patchIndex(key, v.Len()-1)
+		if err != nil {
+			return err
+		}
+		return patchUpdate(ctx, v.Index(idx), path[1:], tags, fn)
+	case reflect.Map:
+		mapKey, err := transformTo(ctx, key, v.Type().Key(), tags...)
+		if err != nil {
+			return err
+		}
+		cur := v.MapIndex(mapKey)
+		if !cur.IsValid() {
+			return wrapError(ErrPatchPathNotFound, key)
+		}
+		item := reflect.New(v.Type().Elem()).Elem()
+		item.Set(cur)
+		if err := patchUpdate(ctx, item, path[1:], tags, fn); err != nil {
+			return err
+		}
+		v.SetMapIndex(mapKey, item)
+		return nil
+	}
+	return wrapError(ErrPatchPathNotFound, key)
+}
+
+func patchAddAt(ctx context.Context, c reflect.Value, key string, value any, tags []string) error {
+	switch c.Kind() {
+	case reflect.Map:
+		if c.IsNil() {
+			c.Set(reflect.MakeMap(c.Type()))
+		}
+		return patchSetMapItem(ctx, c, key, value, tags)
+	case reflect.Slice:
+		idx := c.Len()
+		if key != "-" {
+			var err error
+			if idx, err = patchIndex(key, c.Len()); err != nil {
+				return err
+			}
+		}
+		item, err := transformTo(ctx, value, c.Type().Elem(), tags...)
+		if err != nil {
+			return err
+		}
+		list := reflect.MakeSlice(c.Type(), 0, c.Len()+1)
+		list = reflect.AppendSlice(list, c.Slice(0, idx))
+		list = reflect.Append(list, item)
+		c.Set(reflect.AppendSlice(list, c.Slice(idx, c.Len())))
+		return nil
+	}
+	return patchReplaceAt(ctx, c, key, value, tags)
+}
+
+func patchReplaceAt(ctx context.Context, c reflect.Value, key string, value any, tags []string) error {
+	switch c.Kind() {
+	case reflect.Struct:
+		field, ok := patchStructField(c, key, tags)
+		if !ok {
+			return wrapError(ErrPatchPathNotFound, key)
+		}
+		return setPatchValue(ctx, field, value, tags)
+	case reflect.Slice, reflect.Array:
+		idx, err := patchIndex(key, c.Len()-1)
+		if err != nil {
+			return err
+		}
+		return setPatchValue(ctx, c.Index(idx), value, tags)
+	case reflect.Map:
+		if _, err := patchGet(ctx, c, []string{key}, tags); err != nil {
+			return err
+		}
+		return patchSetMapItem(ctx, c, key, value, tags)
+	}
+	return wrapError(ErrPatchPathNotFound, key)
+}
+
+func patchRemoveAt(ctx context.Context, c reflect.Value, key string, tags []string) error {
+	switch c.Kind() {
+	case reflect.Struct:
+		field, ok := patchStructField(c, key, tags)
+		if !ok {
+			return wrapError(ErrPatchPathNotFound, key)
+		}
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	case reflect.Slice:
+		idx, err := patchIndex(key, c.Len()-1)
+		if err != nil {
+			return err
+		}
+		list := reflect.MakeSlice(c.Type(), 0, c.Len()-1)
+		list = reflect.AppendSlice(list, c.Slice(0, idx))
+		c.Set(reflect.AppendSlice(list, c.Slice(idx+1, c.Len())))
+		return nil
+	case reflect.Array:
+		idx, err := patchIndex(key, c.Len()-1)
+		if err != nil {
+			return err
+		}
+		c.Index(idx).Set(reflect.Zero(c.Type().Elem()))
+		return nil
+	case reflect.Map:
+		mapKey, err := transformTo(ctx, key, c.Type().Key(), tags...)
+		if err != nil {
+			return err
+		}
+		if !c.MapIndex(mapKey).IsValid() {
+			return wrapError(ErrPatchPathNotFound, key)
+		}
+		c.SetMapIndex(mapKey, reflect.Value{})
+		return nil
+	}
+	return wrapError(ErrPatchPathNotFound, key)
+}
+
+func patchSetMapItem(ctx context.Context, c reflect.Value, key string, value any, tags []string) error {
+	mapKey, err := transformTo(ctx, key, c.Type().Key(), tags...)
+	if err != nil {
+		return err
+	}
+	item, err := transformTo(ctx, value, c.Type().Elem(), tags...)
+	if err != nil {
+		return err
+	}
+	c.SetMapIndex(mapKey, item)
+	return nil
+}
+
+// patchIndex parses the array index of the path, the index must not be greater than maxIndex
+func patchIndex(key string, maxIndex int) (int, error) {
+	idx, err := strconv.Atoi(key)
+	if err != nil || idx < 0 || idx > maxIndex || (len(key) > 1 && key[0] == '0') {
+		return 0, wrapError(ErrPatchPathNotFound, "index "+key)
+	}
+	return idx, nil
+}
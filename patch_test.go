@@ -0,0 +1,195 @@
+package gocast
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPatchAddress struct {
+	City string `json:"city"`
+	Zip  int    `json:"zip"`
+}
+
+type testPatchUser struct {
+	ID       int                `json:"id"`
+	Name     string             `json:"name"`
+	Email    string             `json:"email"`
+	Nick     Optional[string]   `json:"nick"`
+	Address  *testPatchAddress  `json:"address"`
+	Tags     []string           `json:"tags"`
+	Labels   map[string]string  `json:"labels"`
+	Settings map[string]any     `json:"settings"`
+	Extra    any                `json:"extra"`
+	Items    []testPatchAddress `json:"items"`
+}
+
+func newTestPatchUser() testPatchUser {
+	return testPatchUser{
+		ID:       1,
+		Name:     "Bob",
+		Email:    "bob@example.com",
+		Address:  &testPatchAddress{City: "Oslo", Zip: 150},
+		Tags:     []string{"a", "b"},
+		Labels:   map[string]string{"env": "dev", "team": "core"},
+		Settings: map[string]any{"theme": map[string]any{"color": "red", "size": 10}},
+		Items:    []testPatchAddress{{City: "Rome"}},
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("merge", func(t *testing.T) {
+		user := newTestPatchUser()
+		var patch map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(`{
+			"name": "Alice",
+			"email": null,
+			"nick": null,
+			"address": {"zip": "0151"},
+			"labels": {"env": "prod", "team": null},
+			"settings": {"theme": {"size": null, "font": "mono"}},
+			"extra": {"a": {"b": 1, "c": null}},
+			"unknown": 1
+		}`), &patch))
+		assert.NoError(t, ApplyPatch(ctx, &user, patch, "json"))
+		assert.Equal(t, 1, user.ID)
+		assert.Equal(t, "Alice", user.Name)
+		assert.Empty(t, user.Email)
+		assert.True(t, user.Nick.IsNull())
+		assert.Equal(t, &testPatchAddress{City: "Oslo", Zip: 151}, user.Address)
+		assert.Equal(t, []string{"a", "b"}, user.Tags)
+		assert.Equal(t, map[string]string{"env": "prod"}, user.Labels)
+		assert.Equal(t, map[string]any{"theme": map[string]any{"color": "red", "font": "mono"}}, user.Settings)
+		assert.Equal(t, map[string]any{"a": map[string]any{"b": float64(1)}}, user.Extra)
+	})
+
+	t.Run("replace_array", func(t *testing.T) {
+		user := newTestPatchUser()
+		assert.NoError(t, ApplyPatch(ctx, &user, map[string]any{"tags": []any{"c"}, "address": nil}, "json"))
+		assert.Equal(t, []string{"c"}, user.Tags)
+		assert.Nil(t, user.Address)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		doc := map[string]any{"a": "b", "c": map[string]any{"d": "e", "f": "g"}}
+		assert.NoError(t, ApplyPatch(ctx, &doc, map[string]any{"a": "z", "c": map[string]any{"f": nil}}))
+		assert.Equal(t, map[string]any{"a": "z", "c": map[string]any{"d": "e"}}, doc)
+	})
+
+	t.Run("error_restores", func(t *testing.T) {
+		user := newTestPatchUser()
+		err := ApplyPatch(ctx, &user, map[string]any{"name": "Alice", "id": "abc", "address": map[string]any{"zip": "x"}}, "json")
+		assert.Error(t, err)
+		assert.Equal(t, newTestPatchUser(), user)
+		assert.ErrorIs(t, ApplyPatch(ctx, user, nil), ErrInvalidParams)
+	})
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("operations", func(t *testing.T) {
+		user := newTestPatchUser()
+		var ops []PatchOperation
+		assert.NoError(t, json.Unmarshal([]byte(`[
+			{"op": "test", "path": "/id", "value": 1},
+			{"op": "replace", "path": "/name", "value": "Alice"},
+			{"op": "add", "path": "/tags/1", "value": "x"},
+			{"op": "add", "path": "/tags/-", "value": "z"},
+			{"op": "remove", "path": "/tags/0"},
+			{"op": "add", "path": "/labels/a~1b", "value": "c"},
+			{"op": "remove", "path": "/labels/team"},
+			{"op": "replace", "path": "/address/zip", "value": "200"},
+			{"op": "add", "path": "/settings/theme/font", "value": "mono"},
+			{"op": "copy", "from": "/address", "path": "/items/-"},
+			{"op": "move", "from": "/email", "path": "/nick"},
+			{"op": "test", "path": "/items/1", "value": {"city": "Oslo", "zip": 200}}
+		]`), &ops))
+		if !assert.NoError(t, ApplyJSONPatch(ctx, &user, ops, "json")) {
+			return
+		}
+		assert.Equal(t, "Alice", user.Name)
+		assert.Equal(t, []string{"x", "b", "z"}, user.Tags)
+		assert.Equal(t, map[string]string{"env": "dev", "a/b": "c"}, user.Labels)
+		assert.Equal(t, 200, user.Address.Zip)
+		assert.Equal(t, "mono", user.Settings["theme"].(map[string]any)["font"])
+		assert.Equal(t, []testPatchAddress{{City: "Rome"}, {City: "Oslo", Zip: 200}}, user.Items)
+		assert.Empty(t, user.Email)
+		assert.Equal(t, NewOptional("bob@example.com"), user.Nick)
+
+		user.Items[1].City = "Paris"
+		assert.Equal(t, "Oslo", user.Address.City, "copied value must not share memory")
+	})
+
+	t.Run("slice_root", func(t *testing.T) {
+		list := []int{1, 2, 3}
+		assert.NoError(t, ApplyJSONPatch(ctx, &list, []PatchOperation{
+			{Op: "remove", Path: "/1"},
+			{Op: "add", Path: "/0", Value: "0"},
+			{Op: "move", From: "/0", Path: "/-"},
+		}))
+		assert.Equal(t, []int{1, 3, 0}, list)
+	})
+
+	t.Run("atomic", func(t *testing.T) {
+		user := newTestPatchUser()
+		err := ApplyJSONPatch(ctx, &user, []PatchOperation{
+			{Op: "replace", Path: "/name", Value: "Alice"},
+			{Op: "test", Path: "/email", Value: "other@example.com"},
+		}, "json")
+		assert.ErrorIs(t, err, ErrPatchTestFailed)
+		assert.Equal(t, newTestPatchUser(), user)
+
+		// Nested pointers are not changed before the patch succeeds
+		address := user.Address
+		err = ApplyJSONPatch(ctx, &user, []PatchOperation{
+			{Op: "replace", Path: "/address/city", Value: "Bergen"},
+			{Op: "replace", Path: "/id", Value: "abc"},
+		}, "json")
+		assert.Error(t, err)
+		assert.Same(t, address, user.Address)
+		assert.Equal(t, "Oslo", address.City)
+	})
+
+	t.Run("state", func(t *testing.T) {
+		type guarded struct {
+			mx     sync.Mutex
+			Name   string `json:"name"`
+			Secret string `json:"secret" copy:"-"`
+			OnSave func() `json:"-"`
+		}
+		val := &guarded{Name: "a", Secret: "s", OnSave: func() {}}
+		val.mx.Lock()
+		assert.NoError(t, ApplyJSONPatch(ctx, val, []PatchOperation{{Op: "replace", Path: "/name", Value: "b"}}, "json"))
+		assert.False(t, val.mx.TryLock(), "the lock state must be kept")
+		val.mx.Unlock()
+		assert.Equal(t, "b", val.Name)
+		assert.Equal(t, "s", val.Secret)
+		assert.NotNil(t, val.OnSave)
+
+		// The test operation converts the value like the other operations
+		assert.NoError(t, ApplyJSONPatch(ctx, val, []PatchOperation{{Op: "test", Path: "/name", Value: []byte("b")}}, "json"))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		user := newTestPatchUser()
+		for _, op := range []PatchOperation{
+			{Op: "replace", Path: "/missing", Value: 1},
+			{Op: "remove", Path: "/labels/missing"},
+			{Op: "replace", Path: "/tags/5", Value: "x"},
+			{Op: "add", Path: "/tags/01", Value: "x"},
+			{Op: "test", Path: "/address/missing"},
+		} {
+			assert.ErrorIs(t, ApplyJSONPatch(ctx, &user, []PatchOperation{op}, "json"), ErrPatchPathNotFound, op.Path)
+		}
+		assert.ErrorIs(t, ApplyJSONPatch(ctx, &user, []PatchOperation{{Op: "unknown", Path: "/id"}}, "json"), ErrInvalidPatch)
+		assert.ErrorIs(t, ApplyJSONPatch(ctx, &user, []PatchOperation{{Op: "add", Path: "id"}}, "json"), ErrInvalidPatch)
+		assert.ErrorIs(t, ApplyJSONPatch(ctx, &user, []PatchOperation{{Op: "move", From: "/address", Path: "/address/city"}}, "json"), ErrInvalidPatch)
+		assert.Equal(t, newTestPatchUser(), user)
+	})
+}
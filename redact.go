@@ -0,0 +1,168 @@
+package gocast
+
+import (
+	"path"
+	"reflect"
+	"strings"
+)
+
+// RedactOption defines the option of the Redact function
+type RedactOption func(opts *redactOptions)
+
+type redactOptions struct {
+	mask string
+	keys []string
+}
+
+// WithRedactMask sets the string replacing the secret strings, "***" by default
+func WithRedactMask(mask string) RedactOption {
+	return func(opts *redactOptions) {
+		opts.mask = mask
+	}
+}
+
+// WithRedactKeys sets the case-insensitive patterns of the secret map keys
+// in the path.Match syntax, "password", "token" and "*_key" by default
+func WithRedactKeys(patterns ...string) RedactOption {
+	return func(opts *redactOptions) {
+		opts.keys = patterns
+	}
+}
+
+type redactor struct {
+	opts    redactOptions
+	visited map[uintptr]bool
+}
+
+// TryRedact returns the deep copy of the value with the secret data masked.
+// Struct fields tagged `secret:"true"` and map items with the keys matching the patterns
+// are replaced: strings by the mask, other values by zero values of the same type,
+// so the result keeps the type of the source. Maps of `map[string]any` trees decoded
+// from JSON are masked by the keys as well.
+// The copy doesn't share memory with the source: the fields tagged `copy:"shallow"`
+// and the types with CopyPolicyShare are copied deeply too, so the source is never changed.
+// Exported channels and functions can't be copied, ErrCopyUnsupportedType is returned for them.
+//
+//	type Login struct {
+//	    User     string `json:"user"`
+//	    Password string `json:"password" secret:"true"`
+//	}
+//	log.Printf("%+v", gocast.Redact(login)) // {User:bob Password:***}
+func TryRedact[T any](v T, opts ...RedactOption) (T, error) {
+	// Copy by the value of T to keep interfaces of the map[string]any trees
+	var dst T
+	copyOpts := CopyOptions{noShare: true}
+	if err := deepCopyWithOptions(reflect.ValueOf(&v).Elem(), reflect.ValueOf(&dst).Elem(), map[uintptr]reflect.Value{}, copyOpts, 0); err != nil {
+		var zero T
+		return zero, err
+	}
+	rd := &redactor{
+		opts:    redactOptions{mask: "***", keys: []string{"password", "token", "*_key"}},
+		visited: map[uintptr]bool{},
+	}
+	for _, opt := range opts {
+		opt(&rd.opts)
+	}
+	rd.redact(reflect.ValueOf(&dst).Elem())
+	return dst, nil
+}
+
+// Redact returns the deep copy of the value with the secret data masked
+// or zero value if the value can't be copied, see TryRedact
+func Redact[T any](v T, opts ...RedactOption) T {
+	dst, err := TryRedact(v, opts...)
+	if err != nil {
+		var zero T
+		return zero
+	}
+	return dst
+}
+
+// redact masks the secrets of the copy in place, the value must be settable
+func (rd *redactor) redact(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || rd.visited[v.Pointer()] {
+			return
+		}
+		rd.visited[v.Pointer()] = true
+		rd.redact(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		// Values stored in the interface are not addressable
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		rd.redact(elem)
+		v.Set(elem)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := accessibleField(v, i)
+			if !field.CanSet() {
+				continue
+			}
+			if Bool(v.Type().Field(i).Tag.Get("secret")) {
+				rd.mask(field)
+			} else {
+				rd.redact(field)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && (v.IsNil() || rd.visited[v.Pointer()]) {
+			return
+		}
+		if v.Kind() == reflect.Slice && v.Len() > 0 {
+			rd.visited[v.Pointer()] = true
+		}
+		for i := 0; i < v.Len(); i++ {
+			rd.redact(v.Index(i))
+		}
+	case reflect.Map:
+		if v.IsNil() || rd.visited[v.Pointer()] {
+			return
+		}
+		rd.visited[v.Pointer()] = true
+		iter := v.MapRange()
+		for iter.Next() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+			if iter.Key().Kind() == reflect.String && rd.isSecretKey(iter.Key().String()) {
+				rd.mask(elem)
+			} else {
+				rd.redact(elem)
+			}
+			v.SetMapIndex(iter.Key(), elem)
+		}
+	}
+}
+
+// mask replaces the value by the mask keeping its type
+func (rd *redactor) mask(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(rd.opts.mask)
+	case reflect.Interface:
+		if !v.IsNil() {
+			v.Set(reflect.ValueOf(rd.opts.mask))
+		}
+	case reflect.Pointer:
+		if !v.IsNil() {
+			elem := reflect.New(v.Type().Elem())
+			rd.mask(elem.Elem())
+			v.Set(elem)
+		}
+	default:
+		v.Set(reflect.Zero(v.Type()))
+	}
+}
+
+func (rd *redactor) isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range rd.opts.keys {
+		if ok, _ := path.Match(strings.ToLower(pattern), key); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package gocast
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testRedactCredentials struct {
+	User     string  `json:"user"`
+	Password string  `json:"password" secret:"true"`
+	PIN      int     `json:"pin" secret:"true"`
+	Token    *string `json:"token" secret:"true"`
+	apiKey   string  `secret:"true"`
+}
+
+type testRedactRequest struct {
+	Method  string                  `json:"method"`
+	Auth    *testRedactCredentials  `json:"auth"`
+	Others  []testRedactCredentials `json:"others"`
+	Headers map[string]string       `json:"headers"`
+	Meta    map[string]any          `json:"meta"`
+	Next    *testRedactRequest      `json:"next"`
+}
+
+func TestRedact(t *testing.T) {
+	token := "secret-token"
+	req := &testRedactRequest{
+		Method: "POST",
+		Auth:   &testRedactCredentials{User: "bob", Password: "pass", PIN: 1234, Token: &token, apiKey: "key"},
+		Others: []testRedactCredentials{{User: "alice", Password: "alice-pass"}},
+		Headers: map[string]string{
+			"Content-Type": "application/json",
+			"Token":        "header-token",
+		},
+		Meta: map[string]any{
+			"api_key": "k",
+			"nested":  map[string]any{"password": "p", "items": []any{map[string]any{"token": 42.0, "name": "n"}}},
+		},
+	}
+	req.Next = req
+
+	t.Run("struct", func(t *testing.T) {
+		res, err := TryRedact(req)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "POST", res.Method)
+		assert.Equal(t, "bob", res.Auth.User)
+		assert.Equal(t, "***", res.Auth.Password)
+		assert.Equal(t, 0, res.Auth.PIN)
+		assert.Equal(t, "***", *res.Auth.Token)
+		assert.Equal(t, "***", res.Auth.apiKey)
+		assert.Equal(t, "***", res.Others[0].Password)
+		assert.Equal(t, map[string]string{"Content-Type": "application/json", "Token": "***"}, res.Headers)
+		assert.Equal(t, map[string]any{
+			"api_key": "***",
+			"nested":  map[string]any{"password": "***", "items": []any{map[string]any{"token": "***", "name": "n"}}},
+		}, res.Meta)
+		assert.Same(t, res, res.Next)
+	})
+
+	t.Run("source_unchanged", func(t *testing.T) {
+		_ = Redact(req)
+		assert.Equal(t, "pass", req.Auth.Password)
+		assert.Equal(t, "secret-token", token)
+		assert.Equal(t, "header-token", req.Headers["Token"])
+		assert.Equal(t, "p", req.Meta["nested"].(map[string]any)["password"])
+	})
+
+	t.Run("json_tree", func(t *testing.T) {
+		var tree any
+		assert.NoError(t, json.Unmarshal([]byte(`{"user":{"name":"bob","Password":"x","session_key":"y"},"ids":[1,2]}`), &tree))
+		res := Redact(tree, WithRedactMask("[hidden]"))
+		assert.Equal(t, map[string]any{
+			"user": map[string]any{"name": "bob", "Password": "[hidden]", "session_key": "[hidden]"},
+			"ids":  []any{1.0, 2.0},
+		}, res)
+	})
+
+	t.Run("custom_keys", func(t *testing.T) {
+		res := Redact(map[string]string{"ssn": "123", "password": "p"}, WithRedactKeys("ssn"))
+		assert.Equal(t, map[string]string{"ssn": "***", "password": "p"}, res)
+	})
+
+	t.Run("shared_source_unchanged", func(t *testing.T) {
+		type session struct {
+			Creds   *testRedactCredentials  `copy:"shallow"`
+			Headers map[string]string       `copy:"shallow"`
+			Items   []testRedactCredentials `copy:"shallow"`
+		}
+		src := session{
+			Creds:   &testRedactCredentials{User: "bob", Password: "p1"},
+			Headers: map[string]string{"token": "t1"},
+			Items:   []testRedactCredentials{{User: "ann", Password: "p2"}},
+		}
+		res, err := TryRedact(src)
+		assert.NoError(t, err)
+		assert.Equal(t, "***", res.Creds.Password)
+		assert.Equal(t, "***", res.Headers["token"])
+		assert.Equal(t, "***", res.Items[0].Password)
+		assert.Equal(t, "p1", src.Creds.Password)
+		assert.Equal(t, "t1", src.Headers["token"])
+		assert.Equal(t, "p2", src.Items[0].Password)
+
+		_, err = TryRedact(struct{ OnDone func() }{})
+		assert.ErrorIs(t, err, ErrCopyUnsupportedType)
+	})
+}
@@ -0,0 +1,142 @@
+package gocast
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ModifierFunc returns the modified field value by the modifier parameter.
+// The value of pointer fields is dereferenced, nil pointers are passed as nil.
+// The result is converted into the field type by the cast pipeline.
+type ModifierFunc func(ctx context.Context, value any, param string) (any, error)
+
+var modifiers sync.Map // map[string]ModifierFunc
+
+func init() {
+	RegisterModifier("trim", modifyString(strings.TrimSpace))
+	RegisterModifier("lower", modifyString(strings.ToLower))
+	RegisterModifier("upper", modifyString(strings.ToUpper))
+	RegisterModifier("default", modifyDefault)
+	RegisterModifier("clamp", modifyClamp)
+}
+
+// RegisterModifier registers the custom modifier of the mod tag,
+// the modifier with the same name is replaced
+//
+//	gocast.RegisterModifier("slug", func(ctx context.Context, value any, param string) (any, error) {
+//	    return strings.ReplaceAll(strings.ToLower(gocast.Str(value)), " ", "-"), nil
+//	})
+func RegisterModifier(name string, fn ModifierFunc) {
+	modifiers.Store(name, fn)
+}
+
+// Sanitize normalizes the struct fields by the modifiers from the `mod` tag.
+// Modifiers are applied in the order of the tag, nested structs, slices and maps are processed recursively,
+// values are set by StructWalkField.SetValue with the same conversion as TryCopyStruct.
+//
+// Built-in modifiers:
+//   - trim, lower, upper — string transformations, other types are not changed
+//   - default=V — sets the value if the field is empty, it must be the last modifier
+//     of the tag, so V is the rest of the tag and it may contain commas
+//   - clamp=MIN MAX — limits the number by the bounds
+//
+// Example:
+//
+//	type Signup struct {
+//	    Email string `json:"email" mod:"trim,lower"`
+//	    Lang  string `json:"lang" mod:"trim,default=en"`
+//	    Age   int    `json:"age" mod:"clamp=0 150"`
+//	}
+//	err := gocast.Sanitize(ctx, &signup)
+func Sanitize(ctx context.Context, v any) error {
+	return StructWalk(ctx, v, func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+		tag := field.Tag("mod")
+		if tag == "" || tag == "-" {
+			return nil
+		}
+		fieldPath := strings.Join(appendPath(path, field.Name()), ".")
+		var original any
+		if value := derefValue(field.RefValue()); value.IsValid() && value.CanInterface() {
+			original = value.Interface()
+		}
+		result := original
+		for _, item := range parseModifierTag(tag) {
+			name, param := item[0], item[1]
+			fn, ok := modifiers.Load(name)
+			if !ok {
+				return wrapError(wrapError(ErrUnknownModifier, name), fieldPath)
+			}
+			var err error
+			if result, err = fn.(ModifierFunc)(ctx, result, param); err != nil {
+				return wrapError(err, fieldPath)
+			}
+		}
+		if reflect.DeepEqual(original, result) {
+			return nil
+		}
+		return wrapError(field.SetValue(ctx, result), fieldPath)
+	}, WalkWithSlices(), WalkWithMaps())
+}
+
+// parseModifierTag splits the tag into the list of modifier names and parameters,
+// the parameter of the default modifier is the rest of the tag
+func parseModifierTag(tag string) [][2]string {
+	var mods [][2]string
+	for tag != "" {
+		var item string
+		item, tag, _ = strings.Cut(tag, ",")
+		name, param, _ := strings.Cut(strings.TrimSpace(item), "=")
+		if name == "default" && tag != "" {
+			param, tag = param+","+tag, ""
+		}
+		if name != "" {
+			mods = append(mods, [2]string{name, param})
+		}
+	}
+	return mods
+}
+
+// modifyString returns the modifier applying the function to string values
+func modifyString(fn func(string) string) ModifierFunc {
+	return func(ctx context.Context, value any, param string) (any, error) {
+		if v := reflect.ValueOf(value); v.Kind() == reflect.String {
+			return fn(v.String()), nil
+		}
+		return value, nil
+	}
+}
+
+func modifyDefault(ctx context.Context, value any, param string) (any, error) {
+	if value == nil || IsEmpty(value) {
+		return param, nil
+	}
+	return value, nil
+}
+
+func modifyClamp(ctx context.Context, value any, param string) (any, error) {
+	bounds := strings.Fields(param)
+	if len(bounds) != 2 {
+		return nil, wrapError(ErrInvalidParams, "clamp "+param)
+	}
+	low, errLow := TryNumber[float64](bounds[0])
+	high, errHigh := TryNumber[float64](bounds[1])
+	if errLow != nil || errHigh != nil {
+		return nil, wrapError(ErrInvalidParams, "clamp "+param)
+	}
+	if value == nil {
+		return value, nil
+	}
+	num, err := TryNumber[float64](value)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case num < low:
+		return low, nil
+	case num > high:
+		return high, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,97 @@
+package gocast
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSanitizeContact struct {
+	Email string `json:"email" mod:"trim,lower"`
+}
+
+type testSanitizeSignup struct {
+	Name     string                         `json:"name" mod:"trim"`
+	Code     string                         `json:"code" mod:"trim,upper"`
+	Lang     string                         `json:"lang" mod:"trim,default=en"`
+	Age      int                            `json:"age" mod:"clamp=0 150"`
+	Score    float64                        `json:"score" mod:"clamp=0 1"`
+	Limit    *int                           `json:"limit" mod:"default=10"`
+	Nick     *string                        `json:"nick" mod:"slug"`
+	Contacts []testSanitizeContact          `json:"contacts"`
+	Extra    map[string]testSanitizeContact `json:"extra"`
+	Raw      string                         `json:"raw"`
+}
+
+func TestSanitize(t *testing.T) {
+	ctx := context.TODO()
+	RegisterModifier("slug", func(ctx context.Context, value any, param string) (any, error) {
+		if value == nil {
+			return nil, nil
+		}
+		return strings.ReplaceAll(strings.ToLower(Str(value)), " ", "-"), nil
+	})
+
+	t.Run("modifiers", func(t *testing.T) {
+		nick := "Big Bob"
+		signup := testSanitizeSignup{
+			Name:     "  Bob ",
+			Code:     " ab ",
+			Lang:     "   ",
+			Age:      200,
+			Score:    -0.5,
+			Nick:     &nick,
+			Contacts: []testSanitizeContact{{Email: " Bob@Example.COM "}},
+			Extra:    map[string]testSanitizeContact{"work": {Email: "WORK@example.com"}},
+			Raw:      " raw ",
+		}
+		if !assert.NoError(t, Sanitize(ctx, &signup)) {
+			return
+		}
+		assert.Equal(t, "Bob", signup.Name)
+		assert.Equal(t, "AB", signup.Code)
+		assert.Equal(t, "en", signup.Lang)
+		assert.Equal(t, 150, signup.Age)
+		assert.Equal(t, 0.0, signup.Score)
+		if assert.NotNil(t, signup.Limit) {
+			assert.Equal(t, 10, *signup.Limit)
+		}
+		assert.Equal(t, "big-bob", *signup.Nick)
+		assert.Equal(t, "bob@example.com", signup.Contacts[0].Email)
+		assert.Equal(t, "work@example.com", signup.Extra["work"].Email)
+		assert.Equal(t, " raw ", signup.Raw)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		signup := testSanitizeSignup{Name: "Bob", Lang: "de", Age: 30}
+		assert.NoError(t, Sanitize(ctx, &signup))
+		assert.Equal(t, 30, signup.Age)
+		assert.Equal(t, "de", signup.Lang)
+		assert.Nil(t, signup.Nick)
+	})
+
+	t.Run("default_with_commas", func(t *testing.T) {
+		type list struct {
+			Hosts string `mod:"trim,default=a.local, b.local"`
+			Ports string `mod:"default=80,443"`
+		}
+		var val list
+		assert.NoError(t, Sanitize(ctx, &val))
+		assert.Equal(t, "a.local, b.local", val.Hosts)
+		assert.Equal(t, "80,443", val.Ports)
+		assert.Equal(t, [][2]string{{"trim", ""}, {"default", "a,b"}}, parseModifierTag("trim, default=a,b"))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		type unknown struct {
+			Value string `mod:"unknown"`
+		}
+		type invalid struct {
+			Value int `mod:"clamp=1"`
+		}
+		assert.ErrorIs(t, Sanitize(ctx, &unknown{}), ErrUnknownModifier)
+		assert.ErrorIs(t, Sanitize(ctx, &invalid{}), ErrInvalidParams)
+	})
+}
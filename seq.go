@@ -0,0 +1,107 @@
+//go:build go1.23
+
+package gocast
+
+import (
+	"context"
+	"iter"
+	"reflect"
+)
+
+// CastSeq converts the items of the sequence lazily, the conversion error
+// is returned with the zero value of the item
+//
+//	for user, err := range gocast.CastSeq[User](rows) {
+//	    ...
+//	}
+func CastSeq[R, S any](seq iter.Seq[S], tags ...string) iter.Seq2[R, error] {
+	return CastSeqContext[R](context.Background(), seq, tags...)
+}
+
+// CastSeqContext converts the items of the sequence lazily like CastSeq,
+// the sequence stops with the error of the context if it's canceled
+func CastSeqContext[R, S any](ctx context.Context, seq iter.Seq[S], tags ...string) iter.Seq2[R, error] {
+	return func(yield func(R, error) bool) {
+		for v := range seq {
+			if err := ctx.Err(); err != nil {
+				var zero R
+				yield(zero, err)
+				return
+			}
+			if !yield(TryCastContext[R](ctx, v, tags...)) {
+				return
+			}
+		}
+	}
+}
+
+// TryCastSeq2 converts the key/value sequence into the map or returns error
+func TryCastSeq2[K comparable, V any, SK, SV any](seq iter.Seq2[SK, SV], tags ...string) (map[K]V, error) {
+	return TryCastSeq2Context[K, V](context.Background(), seq, tags...)
+}
+
+// TryCastSeq2Context converts the key/value sequence into the map or returns error
+func TryCastSeq2Context[K comparable, V any, SK, SV any](ctx context.Context, seq iter.Seq2[SK, SV], tags ...string) (map[K]V, error) {
+	res := map[K]V{}
+	i := 0
+	for k, v := range seq {
+		if err := contextError(ctx, i); err != nil {
+			return nil, wrapError(err, `"`+Str(k)+`" map key`)
+		}
+		i++
+		key, err := TryCastContext[K](ctx, k, tags...)
+		if err == nil {
+			res[key], err = TryCastContext[V](ctx, v, tags...)
+		}
+		if err != nil {
+			return nil, wrapError(err, `"`+Str(k)+`" map key`)
+		}
+	}
+	return res, nil
+}
+
+// CastSeq2 converts the key/value sequence into the map or returns nil
+func CastSeq2[K comparable, V any, SK, SV any](seq iter.Seq2[SK, SV], tags ...string) map[K]V {
+	res, _ := TryCastSeq2[K, V](seq, tags...)
+	return res
+}
+
+// CastSeq2Context converts the key/value sequence into the map or returns nil
+func CastSeq2Context[K comparable, V any, SK, SV any](ctx context.Context, seq iter.Seq2[SK, SV], tags ...string) map[K]V {
+	res, _ := TryCastSeq2Context[K, V](ctx, seq, tags...)
+	return res
+}
+
+// isSeqFunc returns true if the function type is the iterator (iter.Seq, iter.Seq2)
+func isSeqFunc(t reflect.Type) bool {
+	return t.CanSeq() || t.CanSeq2()
+}
+
+// seqFuncValues calls fn for the items of the iterator function,
+// the values of the key/value iterators are used as the items
+func seqFuncValues(ctx context.Context, v reflect.Value, fn func(i int, item reflect.Value) error) (err error) {
+	i := 0
+	if v.Type().CanSeq2() {
+		for _, item := range v.Seq2() {
+			if err = seqItem(ctx, i, item, fn); err != nil {
+				break
+			}
+			i++
+		}
+		return err
+	}
+	for item := range v.Seq() {
+		if err = seqItem(ctx, i, item, fn); err != nil {
+			break
+		}
+		i++
+	}
+	return err
+}
+
+func seqItem(ctx context.Context, i int, item reflect.Value, fn func(i int, item reflect.Value) error) error {
+	if err := contextError(ctx, i); err != nil {
+		return wrapError(err, sliceIndexPath(i))
+	}
+	return fn(i, item)
+}
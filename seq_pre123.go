@@ -0,0 +1,15 @@
+//go:build !go1.23
+
+package gocast
+
+import (
+	"context"
+	"reflect"
+)
+
+// isSeqFunc returns false, the iterator functions are supported since Go 1.23
+func isSeqFunc(t reflect.Type) bool { return false }
+
+func seqFuncValues(ctx context.Context, v reflect.Value, fn func(i int, item reflect.Value) error) error {
+	return nil
+}
@@ -0,0 +1,142 @@
+//go:build go1.23
+
+package gocast
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCastSeq(t *testing.T) {
+	var (
+		res  []int
+		errs int
+	)
+	for v, err := range CastSeq[int](slices.Values([]any{"1", 2, "x", 4.0})) {
+		if err != nil {
+			errs++
+			continue
+		}
+		res = append(res, v)
+	}
+	assert.Equal(t, []int{1, 2, 4}, res)
+	assert.Equal(t, 1, errs)
+
+	t.Run("break", func(t *testing.T) {
+		count := 0
+		for range CastSeq[string](slices.Values([]int{1, 2, 3})) {
+			if count++; count == 2 {
+				break
+			}
+		}
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var (
+			res []int
+			err error
+		)
+		for v, verr := range CastSeqContext[int](ctx, slices.Values([]string{"1", "2", "3"})) {
+			if err = verr; err != nil {
+				break
+			}
+			res = append(res, v)
+			cancel()
+		}
+		assert.Equal(t, []int{1}, res)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestCastSeq2(t *testing.T) {
+	src := map[string]any{"1": "10", "2": 20}
+	res, err := TryCastSeq2[int, string](maps.All(src))
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]string{1: "10", 2: "20"}, res)
+	assert.Equal(t, map[string]int{"0": 1, "1": 2}, CastSeq2[string, int](slices.All([]string{"1", "2"})))
+
+	_, err = TryCastSeq2[int, int](maps.All(map[string]int{"x": 1}))
+	assert.ErrorContains(t, err, `"x" map key`)
+	assert.Nil(t, CastSeq2[int, int](maps.All(map[string]int{"x": 1})))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = TryCastSeq2Context[int, int](ctx, maps.All(map[int]int{1: 1}))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestToAnySliceSeq(t *testing.T) {
+	t.Run("iter.Seq", func(t *testing.T) {
+		res, err := TryAnySlice[int](slices.Values([]string{"1", "2", "3"}))
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, res)
+	})
+
+	t.Run("iter.Seq2 values", func(t *testing.T) {
+		res, err := TryAnySlice[string](slices.All([]int{1, 2}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "2"}, res)
+
+		res, err = TryAnySlice[string](maps.All(map[string]int{"a": 1}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1"}, res)
+	})
+
+	t.Run("parallel", func(t *testing.T) {
+		res, err := TryAnySliceParallel[int](context.Background(), slices.Values([]string{"1", "2", "3"}), 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, res)
+	})
+
+	t.Run("existing destination", func(t *testing.T) {
+		dst := []string{"a", "b", "c"}
+		assert.NoError(t, TryToAnySlice(&dst, slices.Values([]int{1, 2})))
+		assert.Equal(t, []string{"1", "2"}, dst)
+
+		arr := [2]int{}
+		assert.NoError(t, TryToAnySlice(&arr, slices.Values([]string{"1", "2"})))
+		assert.Equal(t, [2]int{1, 2}, arr)
+		assert.ErrorIs(t, TryToAnySlice(&arr, slices.Values([]int{1, 2, 3})), ErrLengthMismatch)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := TryAnySlice[int](slices.Values([]string{"1", "x"}))
+		assert.Error(t, err)
+
+		var nilSeq func(func(int) bool)
+		res, err := TryAnySlice[int](nilSeq)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestToArraySeq(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		truncate = ContextWithArrayLength(ctx, ArrayLengthTruncate)
+		strict   = ContextWithArrayLength(ctx, ArrayLengthStrict)
+	)
+	arr := [2]int{9, 9}
+	assert.NoError(t, TryToAnySlice(&arr, slices.Values([]string{"1"})))
+	assert.Equal(t, [2]int{1, 0}, arr)
+
+	assert.NoError(t, TryToAnySliceContext(truncate, &arr, slices.Values([]int{3, 4, 5})))
+	assert.Equal(t, [2]int{3, 4}, arr)
+
+	assert.ErrorIs(t, TryToAnySliceContext(strict, &arr, slices.Values([]int{1})), ErrLengthMismatch)
+	assert.Equal(t, [2]int{3, 4}, arr)
+
+	// The failed conversion leaves the array unchanged
+	err := TryToAnySlice(&arr, slices.Values([]int{1, 2, 3}))
+	assert.ErrorIs(t, err, ErrLengthMismatch)
+	assert.EqualError(t, err, "array length 2, the sequence is longer: length mismatch")
+	assert.Error(t, TryToAnySlice(&arr, slices.Values([]string{"7", "x"})))
+	assert.Equal(t, [2]int{3, 4}, arr)
+}
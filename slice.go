@@ -21,7 +21,9 @@ package gocast
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"strconv"
 )
 
 // TrySlice converts one type of array to other or returns error
@@ -37,9 +39,12 @@ func TrySliceContext[R any, S any](ctx context.Context, src []S, tags ...string)
 		copy(res, srcArr)
 	default:
 		for i, v := range src {
+			if err = contextError(ctx, i); err != nil {
+				return nil, wrapError(err, sliceIndexPath(i))
+			}
 			var newVal R
 			if newVal, err = TryCastContext[R](ctx, v, tags...); err != nil {
-				return nil, err
+				return nil, wrapContextError(err, sliceIndexPath(i))
 			} else {
 				res[i] = newVal
 			}
@@ -88,26 +93,163 @@ func TryToAnySlice(dst, src any, tags ...string) error {
 	return TryToAnySliceContext(context.Background(), dst, src, tags...)
 }
 
-// TryToAnySliceContext converts any input slice into destination type slice
+// TryToAnySliceContext converts any input slice into destination type slice.
+// The source can also be the receiving channel or, since Go 1.23, the iterator function
+// (iter.Seq, iter.Seq2 values are used), the items are read until the end of the sequence.
+// Strings and scalars are converted if it's enabled by ContextWithSliceCoercion.
 func TryToAnySliceContext(ctx context.Context, dst, src any, tags ...string) error {
-	if dst == nil || src == nil {
-		if dst == nil {
-			return wrapError(ErrInvalidParams, "TryToAnySliceContext `destination` parameter is nil")
+	if dstSlice, err := anySliceDestination(dst, "TryToAnySliceContext"); err == nil {
+		if items, ok, err := coerceSlice(ctx, src, dstSlice.Type().Elem()); err != nil {
+			return err
+		} else if ok {
+			src = items
+		}
+	}
+	if srcSeq := reflectTarget(reflect.ValueOf(src)); src != nil && isSeqValue(srcSeq) {
+		return seqToAnySlice(ctx, dst, srcSeq, tags...)
+	}
+	dstSlice, srcSlice, count, err := prepareAnySlice(ctx, dst, src, "TryToAnySliceContext")
+	if err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		if err := contextError(ctx, i); err != nil {
+			return wrapError(err, sliceIndexPath(i))
+		}
+		if err := setSliceItem(ctx, dstSlice.Index(i), srcSlice.Index(i), tags...); err != nil {
+			return wrapContextError(err, sliceIndexPath(i))
+		}
+	}
+	return nil
+}
+
+// sliceIndexPath returns the path segment of the slice element
+func sliceIndexPath(i int) string {
+	return "[" + strconv.Itoa(i) + "]"
+}
+
+// seqToAnySlice converts the items of the iterator or the channel into the destination slice.
+// The array is filled through the temporary array which is assigned on success only.
+func seqToAnySlice(ctx context.Context, dst any, src reflect.Value, tags ...string) error {
+	dstSlice, err := anySliceDestination(dst, "TryToAnySliceContext")
+	if err != nil {
+		return err
+	}
+	work := dstSlice
+	if dstSlice.Kind() == reflect.Array {
+		work = reflect.New(dstSlice.Type()).Elem()
+	}
+	count := 0
+	err = seqValues(ctx, src, func(i int, item reflect.Value) error {
+		if i >= work.Len() {
+			if work.Kind() == reflect.Array {
+				if arrayLengthPolicy(ctx) == ArrayLengthTruncate {
+					return errSeqStop
+				}
+				// The length of the sequence is unknown until the end, it isn't read further
+				return wrapError(ErrLengthMismatch, "array length "+strconv.Itoa(work.Len())+", the sequence is longer")
+			}
+			work.Set(reflect.Append(work, reflect.Zero(work.Type().Elem())))
+		}
+		count++
+		return wrapContextError(setSliceItem(ctx, work.Index(i), item, tags...), sliceIndexPath(i))
+	})
+	if err == errSeqStop {
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+	if work.Kind() == reflect.Array {
+		// The temporary array is zero-padded already
+		if _, err = arrayItemsCount(ctx, work.Len(), count); err == nil {
+			dstSlice.Set(work)
 		}
-		return wrapError(ErrInvalidParams, "TryToAnySliceContext `source` parameter is nil")
+	} else if count < work.Len() && work.CanSet() {
+		// Drop the items of the existing slice which are left after the sequence
+		work.SetLen(count)
 	}
+	return err
+}
+
+// errSeqStop stops the reading of the sequence without the error
+var errSeqStop = errors.New("stop sequence")
 
+// isSeqValue returns true if the value is the receiving channel
+// or the iterator function (supported since Go 1.23)
+func isSeqValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Chan:
+		return v.Type().ChanDir()&reflect.RecvDir != 0
+	case reflect.Func:
+		return isSeqFunc(v.Type())
+	}
+	return false
+}
+
+// seqValues calls fn for the items of the receiving channel or the iterator function
+func seqValues(ctx context.Context, v reflect.Value, fn func(i int, item reflect.Value) error) error {
+	if v.IsNil() {
+		return nil
+	}
+	if v.Kind() != reflect.Chan {
+		return seqFuncValues(ctx, v, fn)
+	}
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: v},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+	}
+	for i := 0; ; i++ {
+		chosen, item, ok := reflect.Select(cases)
+		if chosen == 1 {
+			return wrapError(ctx.Err(), sliceIndexPath(i))
+		}
+		if !ok {
+			return nil
+		}
+		if err := fn(i, item); err != nil {
+			return err
+		}
+	}
+}
+
+// anySliceDestination checks the destination parameter and returns the target slice or array
+func anySliceDestination(dst any, funcName string) (reflect.Value, error) {
+	if dst == nil {
+		return reflect.Value{}, wrapError(ErrInvalidParams, funcName+" `destination` parameter is nil")
+	}
 	dstSlice := reflectTarget(reflect.ValueOf(dst))
 	if k := dstSlice.Kind(); k != reflect.Slice && k != reflect.Array {
-		return wrapError(ErrInvalidParams, "TryToAnySliceContext `destination` parameter is not a slice or array")
+		return dstSlice, wrapError(ErrInvalidParams, funcName+" `destination` parameter is not a slice or array")
+	}
+	if dstSlice.Kind() == reflect.Array && !dstSlice.CanSet() {
+		return dstSlice, wrapError(ErrInvalidParams, funcName+" `destination` array must be passed by pointer")
+	}
+	return dstSlice, nil
+}
+
+// prepareAnySlice checks the parameters and grows the destination slice to the source length,
+// count is the number of the source items which fit into the destination
+func prepareAnySlice(ctx context.Context, dst, src any, funcName string) (dstSlice, srcSlice reflect.Value, count int, err error) {
+	if dstSlice, err = anySliceDestination(dst, funcName); err != nil {
+		return dstSlice, srcSlice, 0, err
+	}
+	if src == nil {
+		return dstSlice, srcSlice, 0, wrapError(ErrInvalidParams, funcName+" `source` parameter is nil")
 	}
 
-	srcSlice := reflectTarget(reflect.ValueOf(src))
+	srcSlice = reflectTarget(reflect.ValueOf(src))
 	if k := srcSlice.Kind(); k != reflect.Slice && k != reflect.Array {
-		return wrapError(ErrInvalidParams, "TryToAnySliceContext `source` parameter is not a slice or array")
+		return dstSlice, srcSlice, 0, wrapError(ErrInvalidParams, funcName+" `source` parameter is not a slice or array")
 	}
 
-	dstElemType := dstSlice.Type().Elem()
+	// Arrays have the fixed size, the rest of the array is reset
+	if dstSlice.Kind() == reflect.Array {
+		if count, err = arrayItemsCount(ctx, dstSlice.Len(), srcSlice.Len()); err == nil {
+			zeroArrayTail(dstSlice, count)
+		}
+		return dstSlice, srcSlice, count, err
+	}
 
 	if dstSlice.Len() < srcSlice.Len() {
 		newv := reflect.MakeSlice(dstSlice.Type(), srcSlice.Len(), srcSlice.Len())
@@ -115,38 +257,31 @@ func TryToAnySliceContext(ctx context.Context, dst, src any, tags ...string) err
 		dstSlice.Set(newv)
 		dstSlice.SetLen(srcSlice.Len())
 	}
+	return dstSlice, srcSlice, srcSlice.Len(), nil
+}
 
-	for i := 0; i < srcSlice.Len(); i++ {
-		srcItem := srcSlice.Index(i)
-		dstItem := dstSlice.Index(i)
-		if setter, _ := dstItem.Interface().(CastSetter); setter != nil {
-			if dstItem.Kind() == reflect.Pointer && dstItem.IsNil() {
-				dstItem.Set(reflect.New(dstItem.Type().Elem()))
-				setter, _ = dstItem.Interface().(CastSetter)
-			}
-			if err := setter.CastSet(ctx, srcItem.Interface()); err != nil {
-				return err
-			}
-			continue
-		} else if dstItem.CanAddr() {
-			if setter, _ := dstItem.Addr().Interface().(CastSetter); setter != nil {
-				if err := setter.CastSet(ctx, srcItem.Interface()); err != nil {
-					return err
-				}
-				continue
-			}
+// setSliceItem converts the source item into the destination slice item
+func setSliceItem(ctx context.Context, dstItem, srcItem reflect.Value, tags ...string) error {
+	if setter, _ := dstItem.Interface().(CastSetter); setter != nil {
+		if dstItem.Kind() == reflect.Pointer && dstItem.IsNil() {
+			dstItem.Set(reflect.New(dstItem.Type().Elem()))
+			setter, _ = dstItem.Interface().(CastSetter)
 		}
-		if v, err := ReflectTryToTypeContext(ctx, srcItem, dstElemType, true, tags...); err == nil {
-			if v == nil {
-				dstItem.Set(reflect.Zero(dstElemType))
-			} else {
-				dstItem.Set(reflect.ValueOf(v))
-			}
-		} else {
-			return err
+		return setter.CastSet(ctx, srcItem.Interface())
+	} else if dstItem.CanAddr() {
+		if setter, _ := dstItem.Addr().Interface().(CastSetter); setter != nil {
+			return setter.CastSet(ctx, srcItem.Interface())
 		}
 	}
-
+	v, err := ReflectTryToTypeContext(ctx, srcItem, dstItem.Type(), true, tags...)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		dstItem.Set(reflect.Zero(dstItem.Type()))
+	} else {
+		dstItem.Set(reflect.ValueOf(v))
+	}
 	return nil
 }
 
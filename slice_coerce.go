@@ -0,0 +1,163 @@
+package gocast
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// SliceCoercionOption defines the conversion of the non-slice values into slices
+type SliceCoercionOption func(opts *sliceCoercion)
+
+type sliceCoercion struct {
+	separator string
+	scalars   bool
+	json      bool
+}
+
+type sliceCoercionKey struct{}
+
+// WithSliceSeparator splits strings by the separator, the items are trimmed
+// and can be quoted by double quotes to keep the separator: `a, "b,c"` -> [a b,c]
+func WithSliceSeparator(sep string) SliceCoercionOption {
+	return func(opts *sliceCoercion) {
+		opts.separator = sep
+	}
+}
+
+// WithSliceScalars wraps scalar values (booleans, numbers and strings) into one-element slices,
+// maps and structs are not wrapped
+func WithSliceScalars() SliceCoercionOption {
+	return func(opts *sliceCoercion) {
+		opts.scalars = true
+	}
+}
+
+// WithSliceJSON parses strings of JSON arrays like `[1, 2, 3]`
+func WithSliceJSON() SliceCoercionOption {
+	return func(opts *sliceCoercion) {
+		opts.json = true
+	}
+}
+
+// ContextWithSliceCoercion returns the context which enables the conversion of
+// strings and scalars into slices by TryAnySliceContext, TryCastContext and
+// TryCopyStructContext for slice fields
+//
+//	ctx = gocast.ContextWithSliceCoercion(ctx, gocast.WithSliceSeparator(","), gocast.WithSliceScalars())
+//	hosts, err := gocast.TryAnySliceContext[string](ctx, "a.local, b.local") // [a.local b.local]
+func ContextWithSliceCoercion(ctx context.Context, opts ...SliceCoercionOption) context.Context {
+	var coercion sliceCoercion
+	for _, opt := range opts {
+		opt(&coercion)
+	}
+	return context.WithValue(ctx, sliceCoercionKey{}, &coercion)
+}
+
+// coerceSlice converts the non-slice source into the slice of items
+// if the coercion is enabled by the context
+func coerceSlice(ctx context.Context, src any, dstElem reflect.Type) (any, bool, error) {
+	coercion, _ := ctx.Value(sliceCoercionKey{}).(*sliceCoercion)
+	if coercion == nil || src == nil {
+		return nil, false, nil
+	}
+	srcVal := reflectTarget(reflect.ValueOf(src))
+	switch srcVal.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Chan, reflect.Func, reflect.Invalid:
+		return nil, false, nil
+	case reflect.String:
+		// Strings are converted into []byte as is
+		if dstElem.Kind() == reflect.Uint8 {
+			return nil, false, nil
+		}
+		str := srcVal.String()
+		if coercion.json && strings.HasPrefix(strings.TrimSpace(str), "[") {
+			var items []any
+			dec := json.NewDecoder(strings.NewReader(str))
+			dec.UseNumber()
+			if err := dec.Decode(&items); err != nil {
+				return nil, false, wrapError(ErrInvalidParams, "invalid JSON array: "+err.Error())
+			}
+			// Numbers are kept as strings to be converted without the loss of precision
+			for i, item := range items {
+				if num, ok := item.(json.Number); ok {
+					items[i] = num.String()
+				}
+			}
+			return items, true, nil
+		}
+		if coercion.separator != "" {
+			items, err := splitQuoted(str, coercion.separator)
+			return items, err == nil, err
+		}
+	}
+	if coercion.scalars && isScalarKind(srcVal.Kind()) {
+		return []any{srcVal.Interface()}, true, nil
+	}
+	return nil, false, nil
+}
+
+// isScalarKind returns true for booleans, numbers and strings
+func isScalarKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
+// splitQuoted splits the string by the separator, the double-quoted parts
+// keep the separators and `""` is the escaped quote inside of them.
+// ErrInvalidParams is returned if the quoted part is not closed.
+func splitQuoted(s, sep string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return []string{}, nil
+	}
+	var (
+		items  []string
+		item   strings.Builder
+		quoted bool // Inside of the quoted part
+		wasQ   bool // The item has the quoted part, the spaces are kept
+	)
+	flush := func() {
+		if wasQ {
+			items = append(items, item.String())
+		} else {
+			items = append(items, strings.TrimSpace(item.String()))
+		}
+		item.Reset()
+		wasQ = false
+	}
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quoted && s[i] == '"':
+			if i+1 < len(s) && s[i+1] == '"' {
+				item.WriteByte('"')
+				i++
+			} else {
+				quoted = false
+			}
+		case quoted:
+			item.WriteByte(s[i])
+		case s[i] == '"' && strings.TrimSpace(item.String()) == "":
+			item.Reset() // Drop the spaces before the quote
+			quoted, wasQ = true, true
+		case strings.HasPrefix(s[i:], sep):
+			flush()
+			i += len(sep) - 1
+		case wasQ && (s[i] == ' ' || s[i] == '\t'):
+			// Skip the spaces after the quoted part
+		default:
+			item.WriteByte(s[i])
+		}
+	}
+	if quoted {
+		return nil, wrapError(ErrInvalidParams, "unterminated quote in `"+s+"`")
+	}
+	flush()
+	return items, nil
+}
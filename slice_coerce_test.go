@@ -0,0 +1,135 @@
+package gocast
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceCoercion(t *testing.T) {
+	ctx := ContextWithSliceCoercion(context.Background(),
+		WithSliceSeparator(","), WithSliceScalars(), WithSliceJSON())
+
+	tests := []struct {
+		name   string
+		src    any
+		target any
+		fn     func(src any) (any, error)
+	}{
+		{
+			name:   "separator",
+			src:    "a, b ,c",
+			target: []string{"a", "b", "c"},
+			fn:     func(src any) (any, error) { return TryAnySliceContext[string](ctx, src) },
+		},
+		{
+			name:   "quoted",
+			src:    `a, "b, c" , " d ", "e""f"`,
+			target: []string{"a", "b, c", " d ", `e"f`},
+			fn:     func(src any) (any, error) { return TryAnySliceContext[string](ctx, src) },
+		},
+		{
+			name:   "numbers",
+			src:    "1,2, 3",
+			target: []int{1, 2, 3},
+			fn:     func(src any) (any, error) { return TryAnySliceContext[int](ctx, src) },
+		},
+		{
+			name:   "empty string",
+			src:    " ",
+			target: []int{},
+			fn:     func(src any) (any, error) { return TryAnySliceContext[int](ctx, src) },
+		},
+		{
+			name:   "scalar",
+			src:    5,
+			target: []int{5},
+			fn:     func(src any) (any, error) { return TryAnySliceContext[int](ctx, src) },
+		},
+		{
+			name:   "scalar cast",
+			src:    5.0,
+			target: []string{"5"},
+			fn:     func(src any) (any, error) { return TryCastContext[[]string](ctx, src) },
+		},
+		{
+			name:   "json",
+			src:    ` [1, "2", 30000000000]`,
+			target: []int64{1, 2, 30000000000},
+			fn:     func(src any) (any, error) { return TryAnySliceContext[int64](ctx, src) },
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.fn(test.src)
+			assert.NoError(t, err)
+			assert.Equal(t, test.target, res)
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		_, err := TryAnySlice[string]("a,b")
+		assert.ErrorIs(t, err, ErrInvalidParams)
+
+		// Only the enabled coercions are applied
+		sepCtx := ContextWithSliceCoercion(context.Background(), WithSliceSeparator(";"))
+		res, err := TryAnySliceContext[string](sepCtx, "[a;b]")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"[a", "b]"}, res)
+		_, err = TryAnySliceContext[int](sepCtx, 5)
+		assert.ErrorIs(t, err, ErrInvalidParams)
+
+		// Strings are not split into bytes
+		_, err = TryCastContext[[]byte](ctx, "1,2")
+		assert.ErrorIs(t, err, ErrInvalidParams)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := TryAnySliceContext[int](ctx, "[1, 2")
+		assert.ErrorIs(t, err, ErrInvalidParams)
+	})
+
+	t.Run("unterminated quote", func(t *testing.T) {
+		_, err := TryAnySliceContext[string](ctx, `a, "b, c`)
+		assert.ErrorIs(t, err, ErrInvalidParams)
+		_, err = TryCastContext[[]string](ctx, `"a`)
+		assert.ErrorIs(t, err, ErrInvalidParams)
+	})
+
+	t.Run("not scalars", func(t *testing.T) {
+		type item struct{ Name string }
+		_, err := TryAnySliceContext[item](ctx, item{Name: "a"})
+		assert.ErrorIs(t, err, ErrInvalidParams)
+		_, err = TryAnySliceContext[map[string]any](ctx, map[string]any{"a": 1})
+		assert.ErrorIs(t, err, ErrInvalidParams)
+
+		ptr := 5
+		res, err := TryAnySliceContext[int](ctx, &ptr)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{5}, res)
+	})
+
+	t.Run("struct fields", func(t *testing.T) {
+		type config struct {
+			Hosts []string `json:"hosts"`
+			Ports []int    `json:"ports"`
+			Tags  []string `json:"tags"`
+			IDs   []int64  `json:"ids"`
+		}
+		var cfg config
+		err := TryCopyStructContext(ctx, &cfg, map[string]any{
+			"hosts": "a.local, b.local",
+			"ports": 8080,
+			"tags":  []string{"x"},
+			"ids":   "[1, 2]",
+		}, "json")
+		assert.NoError(t, err)
+		assert.Equal(t, config{
+			Hosts: []string{"a.local", "b.local"},
+			Ports: []int{8080},
+			Tags:  []string{"x"},
+			IDs:   []int64{1, 2},
+		}, cfg)
+	})
+}
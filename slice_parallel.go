@@ -0,0 +1,127 @@
+package gocast
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// TryAnySliceParallel converts any input slice into destination type slice
+// using parallelism goroutines, see TryToAnySliceParallel
+func TryAnySliceParallel[R any](ctx context.Context, src any, parallelism int, tags ...string) ([]R, error) {
+	res := []R{}
+	if err := TryToAnySliceParallel(ctx, &res, src, parallelism, tags...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// TryToAnySliceParallel converts any input slice into destination type slice
+// like TryToAnySliceContext but splits the elements between parallelism goroutines,
+// GOMAXPROCS is used if parallelism is less than 1. The channels, iterators and
+// coerced strings are accepted like by TryToAnySliceContext, they are read before the conversion.
+// The order of the elements is preserved. The failed element stops the conversion
+// of the following elements and cancels the context of the other elements, the conversion
+// error with the smallest index is returned wrapped with the path of the element like "[3]: ...".
+// The elements stopped by the cancellation are not reported.
+func TryToAnySliceParallel(ctx context.Context, dst, src any, parallelism int, tags ...string) error {
+	src, isSeq, err := parallelSliceSource(ctx, dst, src)
+	if err != nil {
+		return err
+	}
+	dstSlice, srcSlice, count, err := prepareAnySlice(ctx, dst, src, "TryToAnySliceParallel")
+	if err != nil {
+		return err
+	}
+
+	if parallelism < 1 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	if parallelism > count {
+		parallelism = count
+	}
+	if parallelism < 1 {
+		return ctx.Err() // Empty source
+	}
+
+	parentCtx := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mx       sync.Mutex
+		firstErr error
+		errIndex = count // The smallest index of the failed element
+		chunk    = (count + parallelism - 1) / parallelism
+	)
+	// failed stores the error if the element is before the failed ones and
+	// returns true if the element at index i must not be converted.
+	// The first error cancels the conversion of the other elements, their errors are ignored.
+	failed := func(i int, err error) bool {
+		mx.Lock()
+		defer mx.Unlock()
+		if err != nil && i < errIndex && !isCanceledByError(parentCtx, err) {
+			errIndex, firstErr = i, wrapError(err, sliceIndexPath(i))
+			cancel()
+		}
+		return i >= errIndex
+	}
+	for from := 0; from < count; from += chunk {
+		to := from + chunk
+		if to > count {
+			to = count
+		}
+		wg.Add(1)
+		go func(from, to int) {
+			defer wg.Done()
+			for i := from; i < to && !failed(i, nil); i++ {
+				err := ctx.Err()
+				if err == nil {
+					err = setSliceItem(ctx, dstSlice.Index(i), srcSlice.Index(i), tags...)
+				}
+				if err != nil {
+					failed(i, err)
+					return
+				}
+			}
+		}(from, to)
+	}
+	wg.Wait()
+	if firstErr == nil && isSeq && dstSlice.Kind() == reflect.Slice && count < dstSlice.Len() {
+		// Drop the items of the existing slice which are left after the sequence like seqToAnySlice
+		dstSlice.SetLen(count)
+	}
+	return firstErr
+}
+
+// isCanceledByError returns true if the error is caused by the cancellation
+// of the conversion after the error of another element, not by the parent context
+func isCanceledByError(parentCtx context.Context, err error) bool {
+	return parentCtx.Err() == nil && errors.Is(err, context.Canceled)
+}
+
+// parallelSliceSource returns the source of the parallel conversion,
+// the coerced strings and scalars and the items of the channel or the iterator are read into the slice,
+// isSeq is true if the items are read from the channel or the iterator
+func parallelSliceSource(ctx context.Context, dst, src any) (_ any, isSeq bool, err error) {
+	if dstSlice, err := anySliceDestination(dst, "TryToAnySliceParallel"); err == nil {
+		if items, ok, err := coerceSlice(ctx, src, dstSlice.Type().Elem()); err != nil {
+			return nil, false, err
+		} else if ok {
+			return items, false, nil
+		}
+	}
+	srcSeq := reflectTarget(reflect.ValueOf(src))
+	if src == nil || !isSeqValue(srcSeq) {
+		return src, false, nil
+	}
+	items := []any{}
+	err = seqValues(ctx, srcSeq, func(_ int, item reflect.Value) error {
+		items = append(items, item.Interface())
+		return nil
+	})
+	return items, true, err
+}
@@ -0,0 +1,145 @@
+package gocast
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testSliceWaitStruct fails on "x" and waits for the cancellation of the context otherwise
+type testSliceWaitStruct struct{}
+
+func (it *testSliceWaitStruct) CastSet(ctx context.Context, v any) error {
+	if v == "x" {
+		return errors.New("invalid value")
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(5 * time.Second):
+		return errors.New("context is not canceled")
+	}
+}
+
+func TestTryAnySliceParallel(t *testing.T) {
+	ctx := context.Background()
+	src := make([]map[string]any, 1000)
+	for i := range src {
+		src[i] = map[string]any{"ID": i, "Text": Str(i)}
+	}
+
+	for _, parallelism := range []int{0, 1, 3, 7, 5000} {
+		res, err := TryAnySliceParallel[testSliceStruct](ctx, src, parallelism)
+		if assert.NoError(t, err) && assert.Len(t, res, len(src)) {
+			for i, it := range res {
+				assert.Equal(t, testSliceStruct{ID: i, Text: Str(i)}, it)
+			}
+		}
+	}
+
+	t.Run("cast setter", func(t *testing.T) {
+		res, err := TryAnySliceParallel[testSliceCastStruct](ctx, []int{1, 2, 3}, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []testSliceCastStruct{{Text: "1"}, {Text: "2"}, {Text: "3"}}, res)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		res, err := TryAnySliceParallel[int](ctx, []string{}, 4)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("existing destination", func(t *testing.T) {
+		dst := []int{9, 9, 9, 9}
+		assert.NoError(t, TryToAnySliceParallel(ctx, &dst, []string{"1", "2"}, 2))
+		assert.Equal(t, []int{1, 2, 9, 9}, dst)
+	})
+
+	t.Run("error index", func(t *testing.T) {
+		items := []any{"1", "2", "3", "x", "5", "6"}
+		res, err := TryAnySliceParallel[int](ctx, items, 1)
+		assert.Nil(t, res)
+		if assert.Error(t, err) {
+			assert.True(t, strings.HasPrefix(err.Error(), "[3]: "), err.Error())
+		}
+
+		// The conversion error is returned whichever goroutine fails first,
+		// not the cancellation of the other goroutines
+		items = []any{"1", "x", "3", "4", "x", "6", "x", "8", "9"}
+		for i := 0; i < 50; i++ {
+			_, err = TryAnySliceParallel[int](ctx, items, 3)
+			if assert.Error(t, err) {
+				assert.False(t, errors.Is(err, context.Canceled))
+				assert.Regexp(t, `^\[[146]\]: strconv.ParseInt`, err.Error())
+			}
+		}
+	})
+
+	t.Run("cancel on error", func(t *testing.T) {
+		// The elements in progress are canceled and the original error is returned
+		_, err := TryAnySliceParallel[testSliceWaitStruct](ctx, []string{"wait", "wait", "x"}, 3)
+		assert.EqualError(t, err, "[2]: invalid value")
+	})
+
+	t.Run("channel", func(t *testing.T) {
+		ch := make(chan string, 3)
+		ch <- "1"
+		ch <- "2"
+		ch <- "3"
+		close(ch)
+		res, err := TryAnySliceParallel[int](ctx, ch, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, res)
+
+		// The sequence replaces the items of the existing destination like TryToAnySliceContext
+		ch = make(chan string, 1)
+		ch <- "1"
+		close(ch)
+		dst := []int{9, 9}
+		assert.NoError(t, TryToAnySliceParallel(ctx, &dst, ch, 2))
+		assert.Equal(t, []int{1}, dst)
+	})
+
+	t.Run("coercion", func(t *testing.T) {
+		cctx := ContextWithSliceCoercion(ctx, WithSliceSeparator(","))
+		res, err := TryAnySliceParallel[int](cctx, "1, 2,3", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, res)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := TryAnySliceParallel[testSliceStruct](cctx, src, 4)
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		_, err := TryAnySliceParallel[int](ctx, nil, 2)
+		assert.ErrorIs(t, err, ErrInvalidParams)
+		_, err = TryAnySliceParallel[int](ctx, 1, 2)
+		assert.ErrorIs(t, err, ErrInvalidParams)
+	})
+}
+
+func BenchmarkTryAnySliceParallel(b *testing.B) {
+	ctx := context.Background()
+	src := make([]map[string]any, 10000)
+	for i := range src {
+		src[i] = map[string]any{"ID": i, "Text": Str(i)}
+	}
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = TryAnySliceContext[testSliceStruct](ctx, src)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = TryAnySliceParallel[testSliceStruct](ctx, src, 0)
+		}
+	})
+}
@@ -201,6 +201,62 @@ func TestTrySlice(t *testing.T) {
 	})
 }
 
+func TestToAnySliceChan(t *testing.T) {
+	t.Run("items", func(t *testing.T) {
+		ch := make(chan map[string]any, 3)
+		ch <- map[string]any{"ID": 1, "Text": "a"}
+		ch <- map[string]any{"ID": "2", "Text": "b"}
+		close(ch)
+		res, err := TryAnySlice[testSliceStruct](ch)
+		assert.NoError(t, err)
+		assert.Equal(t, []testSliceStruct{{ID: 1, Text: "a"}, {ID: 2, Text: "b"}}, res)
+
+		// The cast of the channel is not supported by the generic conversion
+		_, err = TryCast[[]int](make(chan int))
+		assert.ErrorIs(t, err, ErrUnsupportedSourceType)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan int, 1)
+		ch <- 1
+		go func() {
+			// The channel is never closed, the conversion stops by the context
+			ch <- 2
+			cancel()
+		}()
+		_, err := TryAnySliceContext[int](ctx, ch)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("existing destination", func(t *testing.T) {
+		ch := make(chan int, 2)
+		ch <- 1
+		ch <- 2
+		close(ch)
+		dst := []string{"a", "b", "c"}
+		assert.NoError(t, TryToAnySlice(&dst, ch))
+		assert.Equal(t, []string{"1", "2"}, dst)
+	})
+
+	t.Run("array", func(t *testing.T) {
+		ch := make(chan string, 3)
+		ch <- "1"
+		ch <- "2"
+		ch <- "3"
+		close(ch)
+		arr := [2]int{9, 9}
+		assert.ErrorIs(t, TryToAnySlice(&arr, ch), ErrLengthMismatch)
+		assert.Equal(t, [2]int{9, 9}, arr, "failed conversion must not change the array")
+
+		ch = make(chan string, 1)
+		ch <- "1"
+		close(ch)
+		assert.NoError(t, TryToAnySlice(&arr, ch))
+		assert.Equal(t, [2]int{1, 0}, arr)
+	})
+}
+
 func TestIsSlice(t *testing.T) {
 	assert.True(t, IsSlice([]int{}))
 	assert.True(t, IsSlice([]bool{}))
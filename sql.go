@@ -0,0 +1,129 @@
+package gocast
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"sort"
+)
+
+// defaultSQLTags is the list of tags used to match columns if no tags were provided
+const defaultSQLTags = "sql,field"
+
+// ScanRows reads all rows into the slice of target type values.
+// Columns are matched to the struct fields by the tag names (sql,field by default),
+// maps receive all columns, and any other types are filled from the single column.
+func ScanRows[T any](rows *sql.Rows, tags ...string) ([]T, error) {
+	return ScanRowsContext[T](context.Background(), rows, tags...)
+}
+
+// ScanRowsContext reads all rows into the slice of target type values.
+func ScanRowsContext[T any](ctx context.Context, rows *sql.Rows, tags ...string) ([]T, error) {
+	if rows == nil {
+		return nil, wrapError(ErrInvalidParams, "ScanRowsContext `rows` parameter is nil")
+	}
+	defer func() { _ = rows.Close() }()
+	var res []T
+	for rows.Next() {
+		item, err := ScanRowContext[T](ctx, rows, tags...)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, item)
+	}
+	return res, rows.Err()
+}
+
+// ScanRow reads the current row into the value of target type.
+// The rows.Next() must be called before.
+func ScanRow[T any](rows *sql.Rows, tags ...string) (T, error) {
+	return ScanRowContext[T](context.Background(), rows, tags...)
+}
+
+// ScanRowContext reads the current row into the value of target type.
+// The rows.Next() must be called before.
+func ScanRowContext[T any](ctx context.Context, rows *sql.Rows, tags ...string) (res T, err error) {
+	if rows == nil {
+		return res, wrapError(ErrInvalidParams, "ScanRowContext `rows` parameter is nil")
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return res, err
+	}
+	var (
+		values = make([]any, len(columns))
+		refs   = make([]any, len(columns))
+	)
+	for i := range values {
+		refs[i] = &values[i]
+	}
+	if err = rows.Scan(refs...); err != nil {
+		return res, err
+	}
+	if len(tags) == 0 {
+		tags = []string{defaultSQLTags}
+	}
+	resType := reflect.TypeOf(&res).Elem()
+	for resType.Kind() == reflect.Pointer {
+		resType = resType.Elem()
+	}
+	switch resType.Kind() {
+	case reflect.Struct, reflect.Map:
+		row := make(map[string]any, len(columns))
+		for i, name := range columns {
+			row[name] = values[i]
+		}
+		if res, err = TryCastContext[T](ctx, row, tags...); err != nil {
+			return res, err
+		}
+	default:
+		if len(columns) != 1 {
+			return res, wrapError(ErrUnsupportedType, resType.String())
+		}
+		if res, err = TryCastContext[T](ctx, values[0], tags...); err != nil {
+			return res, wrapError(err, columns[0])
+		}
+	}
+	return res, nil
+}
+
+// TryStructToNamedArgs converts structure into the list of named arguments for the SQL query
+func TryStructToNamedArgs(v any, tags ...string) ([]sql.NamedArg, error) {
+	if len(tags) == 0 {
+		tags = []string{defaultSQLTags}
+	}
+	values := map[string]any{}
+	if err := TryMapCopy(values, v, false, tags...); err != nil {
+		return nil, err
+	}
+	var (
+		args    = make([]sql.NamedArg, 0, len(values))
+		srcType = reflectTarget(reflect.ValueOf(v)).Type()
+	)
+	// Keep the order of the structure fields
+	if srcType.Kind() == reflect.Struct {
+		for i := 0; i < srcType.NumField(); i++ {
+			name, _ := fieldNameFromTags(srcType.Field(i), tags...)
+			if val, ok := values[name]; ok {
+				args = append(args, sql.Named(name, val))
+				delete(values, name)
+			}
+		}
+	}
+	// The rest of values in the stable order
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, sql.Named(name, values[name]))
+	}
+	return args, nil
+}
+
+// StructToNamedArgs converts structure into the list of named arguments or returns nil
+func StructToNamedArgs(v any, tags ...string) []sql.NamedArg {
+	args, _ := TryStructToNamedArgs(v, tags...)
+	return args
+}
@@ -0,0 +1,65 @@
+package gocast
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// isSQLNullType returns true for sql.NullString, sql.NullInt64, sql.NullTime, sql.Null[T], etc.
+// All of them are structures with the value field and the Valid flag.
+func isSQLNullType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct &&
+		t.PkgPath() == "database/sql" &&
+		strings.HasPrefix(t.Name(), "Null") &&
+		t.NumField() == 2 &&
+		t.Field(1).Name == "Valid" &&
+		t.Field(1).Type.Kind() == reflect.Bool
+}
+
+// sqlNullValue returns the value of sql.Null* type or invalid value in case of NULL
+func sqlNullValue(v reflect.Value) reflect.Value {
+	if !v.Field(1).Bool() {
+		return reflect.Value{}
+	}
+	return v.Field(0)
+}
+
+// unwrapSQLNull returns the value of sql.Null* type and true or the same value and false
+func unwrapSQLNull(v any) (any, bool) {
+	val := reflect.ValueOf(v)
+	if !val.IsValid() || !isSQLNullType(val.Type()) {
+		return v, false
+	}
+	if val = sqlNullValue(val); !val.IsValid() {
+		return nil, true
+	}
+	return val.Interface(), true
+}
+
+// setSQLNullValue puts source value into the sql.Null* destination, NULL values reset Valid flag
+func setSQLNullValue(ctx context.Context, dst reflect.Value, src any, tags ...string) error {
+	srcVal := reflectTarget(reflect.ValueOf(src))
+	if srcVal.IsValid() && srcVal.Type() == dst.Type() {
+		dst.Set(srcVal)
+		return nil
+	}
+	if srcVal.IsValid() && isSQLNullType(srcVal.Type()) {
+		srcVal = sqlNullValue(srcVal)
+	}
+	if k := srcVal.Kind(); k == reflect.Invalid || k == reflect.Pointer || k == reflect.Interface {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	val, err := ReflectTryToTypeContext(ctx, srcVal, dst.Type().Field(0).Type, true, tags...)
+	if err != nil {
+		return err
+	}
+	newVal := reflect.New(dst.Type()).Elem()
+	if val != nil {
+		newVal.Field(0).Set(reflect.ValueOf(val))
+	}
+	newVal.Field(1).SetBool(true)
+	dst.Set(newVal)
+	return nil
+}
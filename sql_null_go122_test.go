@@ -0,0 +1,31 @@
+//go:build go1.22
+
+package gocast
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLNullGeneric(t *testing.T) {
+	t.Run("cast", func(t *testing.T) {
+		assert.Equal(t, sql.Null[int]{V: 7, Valid: true}, Cast[sql.Null[int]]("7"))
+		assert.Equal(t, "5", Str(sql.Null[int]{V: 5, Valid: true}))
+		assert.True(t, IsEmpty(sql.Null[string]{}))
+		assert.False(t, IsEmpty(&sql.Null[string]{V: "x", Valid: true}))
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type target struct {
+			Rate   sql.Null[float64]  `json:"rate"`
+			Parent *sql.Null[float64] `json:"parent"`
+		}
+		var res target
+		err := TryCopyStruct(&res, map[string]any{"rate": "0.5", "parent": 1}, "json")
+		assert.NoError(t, err)
+		assert.Equal(t, sql.Null[float64]{V: 0.5, Valid: true}, res.Rate)
+		assert.Equal(t, &sql.Null[float64]{V: 1, Valid: true}, res.Parent)
+	})
+}
@@ -0,0 +1,106 @@
+package gocast
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLNullDestination(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("cast", func(t *testing.T) {
+		assert.Equal(t, sql.NullString{String: "10", Valid: true}, Cast[sql.NullString](10))
+		assert.Equal(t, sql.NullInt64{Int64: 12, Valid: true}, Cast[sql.NullInt64]("12"))
+		assert.Equal(t, sql.NullInt32{Int32: 1, Valid: true}, Cast[sql.NullInt32](true))
+		assert.Equal(t, sql.NullFloat64{Float64: 1.5, Valid: true}, Cast[sql.NullFloat64]("1.5"))
+		assert.Equal(t, sql.NullBool{Bool: true, Valid: true}, Cast[sql.NullBool]("true"))
+		assert.Equal(t, sql.NullTime{Time: now, Valid: true}, Cast[sql.NullTime](now))
+		assert.Equal(t, sql.NullInt64{Int64: 3, Valid: true}, Cast[sql.NullInt64](sql.NullString{String: "3", Valid: true}))
+		assert.Equal(t, sql.NullInt64{}, Cast[sql.NullInt64](sql.NullString{}))
+		assert.Equal(t, sql.NullString{}, Cast[sql.NullString](any(nil)))
+		assert.Equal(t, sql.NullString{}, Cast[sql.NullString]((*string)(nil)))
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type target struct {
+			Name    sql.NullString `json:"name"`
+			Count   sql.NullInt64  `json:"count"`
+			Created sql.NullTime   `json:"created"`
+		}
+		var res target
+		err := TryCopyStruct(&res, map[string]any{
+			"name":    "test",
+			"count":   nil,
+			"created": "2024-01-02 03:04:05",
+		}, "json")
+		assert.NoError(t, err)
+		assert.Equal(t, sql.NullString{String: "test", Valid: true}, res.Name)
+		assert.Equal(t, sql.NullInt64{}, res.Count)
+		assert.True(t, res.Created.Valid)
+		assert.Equal(t, 2024, res.Created.Time.Year())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := TryCast[sql.NullInt64]("abc")
+		assert.Error(t, err)
+	})
+}
+
+func TestSQLNullSource(t *testing.T) {
+	t.Run("scalar", func(t *testing.T) {
+		assert.Equal(t, 12, Number[int](sql.NullInt64{Int64: 12, Valid: true}))
+		assert.Equal(t, 0, Number[int](sql.NullInt64{Int64: 12}))
+		assert.Equal(t, 1.5, Number[float64](sql.NullString{String: "1.5", Valid: true}))
+		assert.Equal(t, "", Str(sql.NullString{String: "x"}))
+		assert.True(t, Bool(sql.NullBool{Bool: true, Valid: true}))
+		assert.False(t, Bool(sql.NullBool{Bool: true}))
+		assert.Equal(t, int64(3), Cast[int64](sql.NullInt32{Int32: 3, Valid: true}))
+		assert.Equal(t, "x", Cast[string](sql.NullString{String: "x", Valid: true}))
+		assert.Nil(t, Cast[*int](sql.NullInt32{Int32: 3}))
+		assert.Equal(t, 3, *Cast[*int](sql.NullInt32{Int32: 3, Valid: true}))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.True(t, IsEmpty(sql.NullString{String: "x"}))
+		assert.False(t, IsEmpty(sql.NullInt64{Valid: true}))
+		assert.False(t, IsEmptyByReflection(reflect.ValueOf(sql.NullString{Valid: true})))
+		assert.True(t, IsEmptyByReflection(reflect.ValueOf(&sql.NullBool{})))
+		assert.False(t, IsEmpty(sql.NullInt64{Int64: 1, Valid: true}))
+	})
+
+	t.Run("map", func(t *testing.T) {
+		type src struct {
+			Name  sql.NullString `json:"name,omitempty"`
+			Count sql.NullInt32  `json:"count,omitempty"`
+			Rate  sql.NullInt64  `json:"rate"`
+		}
+		m, err := TryMap[string, any](src{Count: sql.NullInt32{Int32: 2, Valid: true}}, "json")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"count": int32(2), "rate": nil}, m)
+
+		// The Valid zero value is kept like the set Optional
+		m, err = TryMap[string, any](src{Name: sql.NullString{Valid: true}}, "json")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"name": "", "rate": nil}, m)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type src struct {
+			Name  sql.NullString
+			Count sql.NullInt32
+			Ptr   sql.NullInt32
+		}
+		type dst struct {
+			Name  string
+			Count int
+			Ptr   *int
+		}
+		var res dst
+		assert.NoError(t, TryCopyStruct(&res, src{Name: sql.NullString{String: "n", Valid: true}, Count: sql.NullInt32{Int32: 4, Valid: true}}))
+		assert.Equal(t, dst{Name: "n", Count: 4}, res)
+	})
+}
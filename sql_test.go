@@ -0,0 +1,192 @@
+package gocast
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testSQLDriver is the in-memory driver which returns predefined rows for each query
+type testSQLDriver struct{}
+
+type testSQLResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+var testSQLResults = map[string]testSQLResult{
+	"users": {
+		columns: []string{"id", "name", "balance", "active", "deleted_at", "created_at"},
+		rows: [][]driver.Value{
+			{int64(1), []byte("Alice"), []byte("10.5"), []byte("1"), nil, []byte("2024-01-02 03:04:05")},
+			{int64(2), "Bob", float64(3), true, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	},
+	"ids": {
+		columns: []string{"id"},
+		rows:    [][]driver.Value{{[]byte("10")}, {int64(20)}},
+	},
+}
+
+func (testSQLDriver) Open(name string) (driver.Conn, error) { return testSQLConn{}, nil }
+
+type testSQLConn struct{}
+
+func (testSQLConn) Prepare(query string) (driver.Stmt, error) { return testSQLStmt{query: query}, nil }
+func (testSQLConn) Close() error                              { return nil }
+func (testSQLConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+type testSQLStmt struct{ query string }
+
+func (testSQLStmt) Close() error                                    { return nil }
+func (testSQLStmt) NumInput() int                                   { return -1 }
+func (testSQLStmt) Exec(args []driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (st testSQLStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &testSQLRows{result: testSQLResults[st.query]}, nil
+}
+
+type testSQLRows struct {
+	result testSQLResult
+	pos    int
+}
+
+func (r *testSQLRows) Columns() []string { return r.result.columns }
+func (r *testSQLRows) Close() error      { return nil }
+func (r *testSQLRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+func init() {
+	sql.Register("gocast_test", testSQLDriver{})
+}
+
+type testSQLUser struct {
+	ID        int64      `sql:"id"`
+	Name      string     `sql:"name"`
+	Balance   float64    `sql:"balance"`
+	Active    bool       `sql:"active"`
+	DeletedAt *time.Time `sql:"deleted_at"`
+	CreatedAt time.Time  `sql:"created_at"`
+	Skipped   string
+}
+
+func TestScanRows(t *testing.T) {
+	db, err := sql.Open("gocast_test", "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer db.Close()
+
+	t.Run("struct", func(t *testing.T) {
+		rows, err := db.Query("users")
+		if !assert.NoError(t, err) {
+			return
+		}
+		users, err := ScanRows[testSQLUser](rows)
+		if assert.NoError(t, err) && assert.Len(t, users, 2) {
+			assert.Equal(t, int64(1), users[0].ID)
+			assert.Equal(t, "Alice", users[0].Name)
+			assert.Equal(t, 10.5, users[0].Balance)
+			assert.True(t, users[0].Active)
+			assert.Nil(t, users[0].DeletedAt)
+			assert.Equal(t, 2024, users[0].CreatedAt.Year())
+			assert.Equal(t, "Bob", users[1].Name)
+			assert.Equal(t, float64(3), users[1].Balance)
+			if assert.NotNil(t, users[1].DeletedAt) {
+				assert.Equal(t, time.February, users[1].DeletedAt.Month())
+			}
+		}
+	})
+
+	t.Run("struct_ptr", func(t *testing.T) {
+		rows, err := db.Query("users")
+		if !assert.NoError(t, err) {
+			return
+		}
+		users, err := ScanRows[*testSQLUser](rows)
+		if assert.NoError(t, err) && assert.Len(t, users, 2) {
+			assert.Equal(t, int64(2), users[1].ID)
+		}
+	})
+
+	t.Run("map", func(t *testing.T) {
+		rows, err := db.Query("users")
+		if !assert.NoError(t, err) {
+			return
+		}
+		items, err := ScanRows[map[string]any](rows)
+		if assert.NoError(t, err) && assert.Len(t, items, 2) {
+			assert.Equal(t, int64(1), items[0]["id"])
+			assert.Nil(t, items[0]["deleted_at"])
+		}
+	})
+
+	t.Run("scalar", func(t *testing.T) {
+		rows, err := db.Query("ids")
+		if !assert.NoError(t, err) {
+			return
+		}
+		ids, err := ScanRows[int](rows)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{10, 20}, ids)
+	})
+
+	t.Run("scalar_multiple_columns", func(t *testing.T) {
+		rows, err := db.Query("users")
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, err = ScanRows[int](rows)
+		assert.ErrorIs(t, err, ErrUnsupportedType)
+	})
+
+	t.Run("row", func(t *testing.T) {
+		rows, err := db.Query("users")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer rows.Close()
+		if assert.True(t, rows.Next()) {
+			user, err := ScanRowContext[testSQLUser](context.Background(), rows)
+			assert.NoError(t, err)
+			assert.Equal(t, "Alice", user.Name)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		_, err := ScanRows[testSQLUser](nil)
+		assert.ErrorIs(t, err, ErrInvalidParams)
+	})
+}
+
+func TestStructToNamedArgs(t *testing.T) {
+	type params struct {
+		ID     int64  `sql:"id"`
+		Name   string `sql:"name,omitempty"`
+		Title  string `field:"title"`
+		Status sql.NullInt64
+	}
+
+	args := StructToNamedArgs(params{ID: 1, Title: "t", Status: sql.NullInt64{Int64: 5, Valid: true}})
+	assert.Equal(t, []sql.NamedArg{
+		sql.Named("id", int64(1)),
+		sql.Named("title", "t"),
+		sql.Named("Status", int64(5)),
+	}, args)
+
+	args = StructToNamedArgs(map[string]any{"b": 2, "a": 1})
+	assert.Equal(t, []sql.NamedArg{sql.Named("a", 1), sql.Named("b", 2)}, args)
+
+	_, err := TryStructToNamedArgs(nil)
+	assert.ErrorIs(t, err, ErrInvalidParams)
+}
@@ -20,12 +20,15 @@
 package gocast
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
 )
 
+var stringType = reflect.TypeOf("")
+
 // TryReflectStr converts reflection value to string
 func TryReflectStr(v reflect.Value) (string, error) {
 	if !v.IsValid() {
@@ -100,6 +103,17 @@ func TryStr(v any) (string, error) {
 	case reflect.Value:
 		return TryReflectStr(reflectTarget(val))
 	}
+	if nv, ok := unwrapSQLNull(v); ok {
+		return TryStr(nv)
+	}
+	if name, ok := enumName(v); ok {
+		return name, nil
+	}
+	if res, ok, err := castGet(context.Background(), v, stringType); err != nil {
+		return ``, err
+	} else if ok {
+		return TryStr(res)
+	}
 	val := reflectTarget(reflect.ValueOf(v))
 	return fmt.Sprintf("%v", val.Interface()), nil
 }
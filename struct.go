@@ -53,12 +53,43 @@ func TryCopyStructContext(ctx context.Context, dst, src any, tags ...string) (er
 	}
 
 	var (
-		destVal        = reflectTarget(reflect.ValueOf(dst))
-		destType       = destVal.Type()
+		destVal  = reflectTarget(reflect.ValueOf(dst))
+		destType = destVal.Type()
+	)
+
+	// Set sql.Null* value from the scalar source
+	if isSQLNullType(destType) {
+		return setSQLNullValue(ctx, destVal, src, tags...)
+	}
+
+	// Let the source define its own representation of the target structure
+	if res, ok, err := castGet(ctx, src, destType); err != nil {
+		return err
+	} else if ok {
+		if res == nil {
+			destVal.Set(reflect.Zero(destType))
+			return nil
+		}
+		if rv := reflectTarget(reflect.ValueOf(res)); rv.Type() == destType {
+			destVal.Set(rv)
+			return nil
+		}
+		src = res
+	}
+
+	// Use the generated converter instead of the reflection
+	if srcMap, ok := src.(map[string]any); ok {
+		if copier, ok := generatedMapCopier(dst, tags); ok {
+			return copier.CopyFromContext(ctx, srcMap)
+		}
+	}
+
+	var (
 		destFieldTypes = ReflectStructFields(destType)
 		srcVal         = reflectTarget(reflect.ValueOf(src))
 		names          []string
 		v              any
+		found          bool
 	)
 
 	// Check source type is map or struct, otherwise return error unsupported type
@@ -67,7 +98,10 @@ func TryCopyStructContext(ctx context.Context, dst, src any, tags ...string) (er
 	}
 
 	// Iterate over destination fields and set values from source
-	for _, ft := range destFieldTypes {
+	for i, ft := range destFieldTypes {
+		if err = contextError(ctx, i); err != nil {
+			return wrapError(err, ft.Name)
+		}
 		field := destVal.FieldByName(ft.Name)
 		if !field.CanSet() {
 			continue
@@ -80,50 +114,68 @@ func TryCopyStructContext(ctx context.Context, dst, src any, tags ...string) (er
 
 		// Get value from map or struct
 		if srcVal.Kind() == reflect.Map {
-			v = reflectMapValueByStringKeys(srcVal, names)
+			v, found = reflectMapValueByStringKeys(srcVal, names)
 		} else {
-			v, _ = ReflectStructFieldValue(srcVal, names...)
+			v, err = ReflectStructFieldValue(srcVal, names...)
+			found, err = err == nil, nil
 		}
 
 		// Set field value
-		if v == nil {
-			err = setFieldValueReflect(ctx, field, reflect.Zero(field.Type()))
+		if err = setStructField(ctx, field, ft, v, found, tags...); err != nil {
+			err = wrapError(err, ft.Name)
+			break
+		}
+	}
+
+	return err
+}
+
+// setStructField puts the source value into the struct field, found reports
+// the presence of the nil value in the source to set the explicit null of Optional fields
+func setStructField(ctx context.Context, field reflect.Value, ft reflect.StructField, v any, found bool, tags ...string) (err error) {
+	if v == nil {
+		if opt, _ := field.Addr().Interface().(optionalSetter); opt != nil && found {
+			opt.SetNull() // Explicit null differs from the absent value
 		} else {
-			switch field.Kind() {
-			case reflect.Struct:
-				err = TryCopyStructContext(ctx, field.Addr().Interface(), v, tags...)
-			default:
-				var (
-					vl any
-					ok = false
-				)
-				if setter, _ := field.Interface().(CastSetter); setter != nil {
+			err = setFieldValueReflect(ctx, field, reflect.Zero(field.Type()))
+		}
+	} else {
+		// Validate and convert the value by the list of names from the enum tag
+		if enumTag := ft.Tag.Get("enum"); enumTag != "" {
+			if v, err = enumTagValue(enumTag, field.Type(), v); err != nil {
+				return err
+			}
+		}
+		switch field.Kind() {
+		case reflect.Struct:
+			err = TryCopyStructContext(ctx, field.Addr().Interface(), v, tags...)
+		default:
+			var (
+				vl any
+				ok = false
+			)
+			if setter, _ := field.Interface().(CastSetter); setter != nil {
+				err = setter.CastSet(ctx, v)
+				ok = true
+			} else if field.CanAddr() {
+				if setter, _ := field.Addr().Interface().(CastSetter); setter != nil {
 					err = setter.CastSet(ctx, v)
 					ok = true
-				} else if field.CanAddr() {
-					if setter, _ := field.Addr().Interface().(CastSetter); setter != nil {
-						err = setter.CastSet(ctx, v)
-						ok = true
-					}
 				}
-				if !ok {
-					if vl, err = TryToTypeContext(ctx, v, field.Type(), tags...); err == nil {
-						val := reflect.ValueOf(vl)
-						if val.Kind() == reflect.Ptr && field.Kind() != reflect.Ptr {
-							val = val.Elem()
-						}
-						err = setFieldValueReflect(ctx, field, val)
+			}
+			if !ok {
+				if vl, err = TryToTypeContext(ctx, v, field.Type(), tags...); err == nil {
+					val := reflect.ValueOf(vl)
+					if vl == nil {
+						val = reflect.Zero(field.Type())
+					} else if val.Kind() == reflect.Ptr && field.Kind() != reflect.Ptr {
+						val = val.Elem()
 					}
+					err = setFieldValueReflect(ctx, field, val)
 				}
 			}
 		}
-
-		if err != nil {
-			err = wrapError(err, ft.Name)
-			break
-		}
 	}
-
 	return err
 }
 
@@ -374,32 +426,43 @@ func setFieldValueReflect(ctx context.Context, field, value reflect.Value) (err
 }
 
 func setFieldTimeValue(field reflect.Value, value any) (err error) {
+	var tm time.Time
 	switch v := value.(type) {
 	case nil:
-		s := reflectTarget(field)
-		s.Set(reflect.ValueOf(time.Time{}))
 	case time.Time:
-		s := reflectTarget(field)
-		s.Set(reflect.ValueOf(v))
+		tm = v
 	case *time.Time:
-		s := reflectTarget(field)
-		s.Set(reflect.ValueOf(*v))
-	case string:
-		var tm time.Time
-		if tm, err = ParseTime(v); err == nil {
-			s := reflectTarget(field)
-			s.Set(reflect.ValueOf(tm))
+		if v == nil {
+			value = nil
+		} else {
+			tm = *v
 		}
+	case []byte:
+		tm, err = ParseTime(string(v))
+	case string:
+		tm, err = ParseTime(v)
 	case int64:
-		s := reflectTarget(field)
-		s.Set(reflect.ValueOf(time.Unix(v, 0)))
+		tm = time.Unix(v, 0)
 	case uint64:
-		s := reflectTarget(field)
-		s.Set(reflect.ValueOf(time.Unix(int64(v), 0)))
+		tm = time.Unix(int64(v), 0)
 	default:
-		err = wrapError(ErrUnsupportedType, field.String())
+		return wrapError(ErrUnsupportedType, field.String())
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	// Nullable time field keeps nil for the empty value
+	if field.Kind() == reflect.Pointer && field.CanSet() {
+		if value == nil {
+			field.Set(reflect.Zero(field.Type()))
+			return nil
+		}
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+	}
+	reflectTarget(field).Set(reflect.ValueOf(tm))
+	return nil
 }
 
 func fieldNames(f reflect.StructField, tags ...string) []string {
@@ -3,12 +3,18 @@ package gocast
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unsafe"
 )
 
 var (
-	ErrWalkSkip = errors.New("skip field walk")
-	ErrWalkStop = errors.New("stop field walk")
+	ErrWalkSkip  = errors.New("skip field walk")
+	ErrWalkStop  = errors.New("stop field walk")
+	ErrWalkCycle = errors.New("circular reference in field walk")
 )
 
 type StructWalkObject interface {
@@ -24,7 +30,7 @@ type structWalkObject struct {
 
 func (obj *structWalkObject) Parent() StructWalkObject { return obj.parent }
 func (obj *structWalkObject) RefValue() reflect.Value  { return obj.strct }
-func (obj *structWalkObject) Struct() any              { return obj.strct.Interface() }
+func (obj *structWalkObject) Struct() any              { return walkValueInterface(obj.strct) }
 
 // StructWalkField is the type of the field visited by StructWalk
 type StructWalkField interface {
@@ -33,13 +39,32 @@ type StructWalkField interface {
 	IsEmpty() bool
 	RefValue() reflect.Value
 	Value() any
+	// SetValue converts and sets the value, the changed map element is written
+	// back into the map after the walk of the element
 	SetValue(ctx context.Context, v any) error
+	// Key returns the index of the slice element or the key of the map element,
+	// nil for struct fields
+	Key() any
+	// Index returns the index of the field in the struct, -1 for collection elements
+	Index() int
+	Type() reflect.Type
+	IsEmbedded() bool
+	// StructField returns the field description, empty for collection elements
+	StructField() reflect.StructField
 }
 
 type structWalkField struct {
 	name      string
+	key       any
+	index     int
 	fieldVal  reflect.Value
 	fieldType reflect.StructField
+
+	// Map elements are not addressable, the value is written back by the key
+	mapVal reflect.Value
+	mapKey reflect.Value
+
+	state *structWalkState
 }
 
 func (fl *structWalkField) Name() string {
@@ -51,6 +76,9 @@ func (fl *structWalkField) Tag(name string) string {
 }
 
 func (fl *structWalkField) IsEmpty() bool {
+	if !fl.fieldVal.CanInterface() {
+		return IsEmptyByReflection(fl.fieldVal)
+	}
 	return IsEmpty(fl.Value())
 }
 
@@ -59,14 +87,75 @@ func (fl *structWalkField) RefValue() reflect.Value {
 }
 
 func (fl *structWalkField) Value() any {
-	return fl.fieldVal.Interface()
+	return walkValueInterface(fl.fieldVal)
+}
+
+func (fl *structWalkField) Key() any {
+	return fl.key
+}
+
+func (fl *structWalkField) Index() int {
+	return fl.index
+}
+
+func (fl *structWalkField) Type() reflect.Type {
+	return fl.fieldVal.Type()
+}
+
+func (fl *structWalkField) IsEmbedded() bool {
+	return fl.fieldType.Anonymous
+}
+
+func (fl *structWalkField) StructField() reflect.StructField {
+	return fl.fieldType
 }
 
 func (fl *structWalkField) SetValue(ctx context.Context, v any) error {
 	if !fl.fieldVal.CanSet() {
 		return wrapError(ErrStructFieldValueCantBeChanged, fl.name)
 	}
-	return setFieldValueNoCastSetter(ctx, fl.fieldVal, v, true)
+	if err := setFieldValueNoCastSetter(ctx, fl.fieldVal, v, true); err != nil {
+		return err
+	}
+	if fl.state != nil {
+		fl.state.changes++
+	}
+	fl.writeBack()
+	return nil
+}
+
+// writeBack puts the copy of the map element back into the map
+func (fl *structWalkField) writeBack() {
+	if fl.mapVal.IsValid() && fl.mapVal.CanInterface() {
+		fl.mapVal.SetMapIndex(fl.mapKey, fl.fieldVal)
+	}
+}
+
+// walkValueInterface returns the value as interface, values of unexported fields
+// are read by the address or returned as nil if the struct is not addressable
+func walkValueInterface(v reflect.Value) any {
+	switch {
+	case v.CanInterface():
+		return v.Interface()
+	case v.CanAddr():
+		return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem().Interface()
+	}
+	return nil
+}
+
+// walkCycleKey identifies the value on the current walk branch
+type walkCycleKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// structWalkState is the state of the single StructWalk call
+type structWalkState struct {
+	opt      *StructWalkOptions
+	walker   structWalkerFunc
+	depth    int
+	visiting map[walkCycleKey]bool
+	changes  int // the number of the values changed by SetValue
 }
 
 // StructWalk walks the struct recursively
@@ -79,37 +168,132 @@ func StructWalk(ctx context.Context, v any, walker structWalkerFunc, options ...
 	for _, o := range options {
 		o(&opt)
 	}
-	err := _structWalk(ctx, &structWalkObject{strct: structVal}, walker, &opt)
+	st := &structWalkState{opt: &opt, walker: walker, visiting: map[walkCycleKey]bool{}}
+	if key, ok := walkValueCycleKey(structVal); ok {
+		st.visiting[key] = true
+	}
+	err := st.walkStruct(ctx, &structWalkObject{strct: structVal}, nil)
 	return IfThen(errors.Is(err, ErrWalkStop), nil, err)
 }
 
-func _structWalk(ctx context.Context, v StructWalkObject, walker structWalkerFunc, opt *StructWalkOptions, path ...string) error {
+func (st *structWalkState) walkStruct(ctx context.Context, v StructWalkObject, path []string) error {
 	var (
-		err           error
 		structVal     = v.RefValue()
 		structValType = structVal.Type()
 	)
 	for i := 0; i < structVal.NumField(); i++ {
 		field := structVal.Field(i)
 		fieldType := structValType.Field(i)
-		if !fieldType.IsExported() {
+		if !fieldType.IsExported() && !st.opt.walkUnexported {
 			continue
 		}
 		fieldWrapper := structWalkField{
 			name:      fieldType.Name,
+			index:     i,
 			fieldVal:  field,
 			fieldType: fieldType,
+			state:     st,
 		}
-		if err = walker(ctx, v, &fieldWrapper, path); err == nil {
-			if stTrg := reflectTarget(field); stTrg.Kind() == reflect.Struct {
-				newV := structWalkObject{parent: v, strct: stTrg}
-				pathName := opt.PathName(ctx, v, &fieldWrapper, path)
-				err = _structWalk(ctx, &newV, walker, opt, append(path, pathName)...)
+		// Fields of the flattened embedded struct belong to the parent's path
+		flatten := fieldType.Anonymous && st.opt.flattenEmbedded && reflectTarget(field).Kind() == reflect.Struct
+		if err := st.walkField(ctx, v, &fieldWrapper, path, flatten); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkField visits the field, descends into its value and calls the leave callback
+func (st *structWalkState) walkField(ctx context.Context, v StructWalkObject, field *structWalkField, path []string, flatten bool) error {
+	err := st.walker(ctx, v, field, path)
+	if err == nil && st.opt.isWalkable(field.fieldVal) {
+		fieldPath := path
+		switch {
+		case flatten:
+		case field.index < 0: // Collection elements are named by the index or key
+			fieldPath = appendPath(path, field.name)
+		default:
+			fieldPath = appendPath(path, st.opt.PathName(ctx, v, field, path))
+		}
+		err = st.walkValue(ctx, v, field.fieldVal, fieldPath)
+	}
+	if err == nil || errors.Is(err, ErrWalkSkip) {
+		if st.opt.onLeave != nil {
+			err = st.opt.onLeave(ctx, v, field, path)
+		}
+	}
+	if err != nil && !errors.Is(err, ErrWalkSkip) {
+		return err
+	}
+	return nil
+}
+
+// walkValue descends into the struct or the elements of the collection
+func (st *structWalkState) walkValue(ctx context.Context, v StructWalkObject, val reflect.Value, path []string) error {
+	trg := reflectTarget(val)
+	if st.opt.maxDepth > 0 && st.depth+1 >= st.opt.maxDepth {
+		return nil
+	}
+	if key, ok := walkValueCycleKey(trg); ok {
+		if st.visiting[key] {
+			if st.opt.cycleError {
+				return wrapError(ErrWalkCycle, strings.Join(path, "."))
 			}
+			return nil
 		}
-		if err != nil && !errors.Is(err, ErrWalkSkip) {
-			return err
+		st.visiting[key] = true
+		defer delete(st.visiting, key)
+	}
+	st.depth++
+	defer func() { st.depth-- }()
+
+	switch trg.Kind() {
+	case reflect.Struct:
+		return st.walkStruct(ctx, &structWalkObject{parent: v, strct: trg}, path)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < trg.Len(); i++ {
+			elem := structWalkField{name: strconv.Itoa(i), key: i, index: -1, fieldVal: trg.Index(i), state: st}
+			if err := st.walkField(ctx, v, &elem, path, false); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := trg.MapKeys()
+		names := make(map[reflect.Value]string, len(keys))
+		for _, key := range keys {
+			names[key] = fmt.Sprint(key) // Keys of unexported maps can't be converted to interface
+		}
+		sort.Slice(keys, func(i, j int) bool { return names[keys[i]] < names[keys[j]] })
+		for _, key := range keys {
+			elem := structWalkField{name: names[key], index: -1, fieldVal: trg.MapIndex(key), state: st}
+			if trg.CanInterface() {
+				// Walk the copy of the element to make it changeable
+				elem.key = key.Interface()
+				elem.fieldVal = reflect.New(trg.Type().Elem()).Elem()
+				elem.fieldVal.Set(trg.MapIndex(key))
+				elem.mapVal, elem.mapKey = trg, key
+			}
+			changes := st.changes
+			err := st.walkField(ctx, v, &elem, path, false)
+			if st.changes != changes {
+				// The element or its nested values are changed by SetValue
+				elem.writeBack()
+			}
+			if err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
+
+// walkValueCycleKey returns the key of the value which can be met again by the circular reference
+func walkValueCycleKey(v reflect.Value) (walkCycleKey, bool) {
+	switch {
+	case (v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && v.Len() > 0:
+		return walkCycleKey{ptr: v.Pointer(), typ: v.Type()}, true
+	case v.CanAddr():
+		return walkCycleKey{ptr: v.UnsafeAddr(), typ: v.Type()}, true
+	}
+	return walkCycleKey{}, false
+}
@@ -1,6 +1,9 @@
 package gocast
 
-import "context"
+import (
+	"context"
+	"reflect"
+)
 
 type (
 	structWalkerFunc     func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error
@@ -11,6 +14,15 @@ type (
 type StructWalkOptions struct {
 	pathTag       string
 	pathExtractor structWalkerNameFunc
+	walkSlices    bool
+	walkMaps      bool
+
+	walkUnexported  bool
+	flattenEmbedded bool
+
+	onLeave    structWalkerFunc
+	maxDepth   int
+	cycleError bool
 }
 
 // PathName returns the path name of the current field
@@ -24,6 +36,20 @@ func (w *StructWalkOptions) PathName(ctx context.Context, curObj StructWalkObjec
 	return field.Name()
 }
 
+// isWalkable returns true if the target of the value is a struct
+// or the collection enabled by options
+func (w *StructWalkOptions) isWalkable(v reflect.Value) bool {
+	switch trg := reflectTarget(v); trg.Kind() {
+	case reflect.Struct:
+		return true
+	case reflect.Slice, reflect.Array:
+		return w.walkSlices && trg.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Map:
+		return w.walkMaps
+	}
+	return false
+}
+
 // WalkOption is the option for StructWalk
 type WalkOption func(w *StructWalkOptions)
 
@@ -40,3 +66,59 @@ func WalkWithPathTag(tagName string) WalkOption {
 		w.pathTag = tagName
 	}
 }
+
+// WalkWithSlices makes StructWalk descend into the elements of slices and arrays,
+// every element is visited as the field named by the index
+func WalkWithSlices() WalkOption {
+	return func(w *StructWalkOptions) {
+		w.walkSlices = true
+	}
+}
+
+// WalkWithMaps makes StructWalk descend into the elements of maps,
+// every element is visited as the field named by the key
+func WalkWithMaps() WalkOption {
+	return func(w *StructWalkOptions) {
+		w.walkMaps = true
+	}
+}
+
+// WalkWithUnexported makes StructWalk visit unexported fields,
+// they are read-only and SetValue returns ErrStructFieldValueCantBeChanged
+func WalkWithUnexported() WalkOption {
+	return func(w *StructWalkOptions) {
+		w.walkUnexported = true
+	}
+}
+
+// WalkWithFlattenEmbedded makes StructWalk add fields of embedded structs
+// into the path of the parent struct without the embedded struct name
+func WalkWithFlattenEmbedded() WalkOption {
+	return func(w *StructWalkOptions) {
+		w.flattenEmbedded = true
+	}
+}
+
+// WalkWithOnLeave sets the callback called after the nested fields of the field are walked,
+// it is useful for the bottom-up aggregation
+func WalkWithOnLeave(fn structWalkerFunc) WalkOption {
+	return func(w *StructWalkOptions) {
+		w.onLeave = fn
+	}
+}
+
+// WalkWithMaxDepth limits the number of nested levels visited by StructWalk,
+// 1 visits only the fields of the root struct
+func WalkWithMaxDepth(depth int) WalkOption {
+	return func(w *StructWalkOptions) {
+		w.maxDepth = depth
+	}
+}
+
+// WalkWithCycleError makes StructWalk return ErrWalkCycle on the circular reference,
+// by default the value met again on the same branch is not walked
+func WalkWithCycleError() WalkOption {
+	return func(w *StructWalkOptions) {
+		w.cycleError = true
+	}
+}
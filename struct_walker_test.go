@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -234,3 +235,261 @@ func TestStructWalk(t *testing.T) {
 		assert.True(t, reflect.DeepEqual(testStruct, targetStruct), "compare struct: %#v", testStruct)
 	})
 }
+
+func TestStructWalkCollections(t *testing.T) {
+	type (
+		item struct {
+			Name string `field:"name"`
+		}
+		container struct {
+			Items []item            `field:"items"`
+			Ptrs  []*item           `field:"ptrs"`
+			Grid  [1][]item         `field:"grid"`
+			ByKey map[string]item   `field:"by_key"`
+			Tags  map[string]string `field:"tags"`
+			Data  []byte            `field:"data"`
+		}
+	)
+	ctx := context.TODO()
+	newContainer := func() *container {
+		return &container{
+			Items: []item{{Name: "a"}, {Name: "b"}},
+			Ptrs:  []*item{{Name: "c"}, nil},
+			Grid:  [1][]item{{{Name: "d"}}},
+			ByKey: map[string]item{"x": {Name: "e"}},
+			Tags:  map[string]string{"k": "v"},
+			Data:  []byte("data"),
+		}
+	}
+
+	t.Run("paths", func(t *testing.T) {
+		var paths []string
+		err := StructWalk(ctx, newContainer(), func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+			paths = append(paths, strings.Join(append(path, field.Name()), "."))
+			return nil
+		}, WalkWithPathTag("field"), WalkWithSlices(), WalkWithMaps())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"Items", "items.0", "items.0.Name", "items.1", "items.1.Name",
+			"Ptrs", "ptrs.0", "ptrs.0.Name", "ptrs.1",
+			"Grid", "grid.0", "grid.0.0", "grid.0.0.Name",
+			"ByKey", "by_key.x", "by_key.x.Name",
+			"Tags", "tags.k",
+			"Data",
+		}, paths)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		count := 0
+		err := StructWalk(ctx, newContainer(), func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+			count++
+			assert.Nil(t, field.Key())
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 6, count)
+	})
+
+	t.Run("set_value", func(t *testing.T) {
+		obj := newContainer()
+		err := StructWalk(ctx, obj, func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+			if s, ok := field.Value().(string); ok {
+				return field.SetValue(ctx, strings.ToUpper(s))
+			}
+			return nil
+		}, WalkWithSlices(), WalkWithMaps())
+		assert.NoError(t, err)
+		assert.Equal(t, []item{{Name: "A"}, {Name: "B"}}, obj.Items)
+		assert.Equal(t, "C", obj.Ptrs[0].Name)
+		assert.Equal(t, "D", obj.Grid[0][0].Name)
+		assert.Equal(t, map[string]item{"x": {Name: "E"}}, obj.ByKey)
+		assert.Equal(t, map[string]string{"k": "V"}, obj.Tags)
+	})
+
+	t.Run("skip_element", func(t *testing.T) {
+		var names []string
+		err := StructWalk(ctx, newContainer(), func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+			if field.Key() == 0 {
+				return ErrWalkSkip
+			}
+			if field.Name() == "Name" {
+				names = append(names, field.Value().(string))
+			}
+			return nil
+		}, WalkWithSlices(), WalkWithMaps())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b", "e"}, names)
+	})
+
+	t.Run("concurrent_read", func(t *testing.T) {
+		// Read-only walks don't write the map elements back, so they can run in parallel
+		obj := newContainer()
+		for i := 0; i < 4; i++ {
+			t.Run(strconv.Itoa(i), func(t *testing.T) {
+				t.Parallel()
+				for j := 0; j < 500; j++ {
+					count := 0
+					err := StructWalk(ctx, obj, func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+						count++
+						return nil
+					}, WalkWithSlices(), WalkWithMaps())
+					assert.NoError(t, err)
+					assert.Equal(t, 19, count)
+				}
+			})
+		}
+	})
+}
+
+func TestStructWalkUnexportedAndEmbedded(t *testing.T) {
+	type (
+		Base struct {
+			ID int `field:"id"`
+		}
+		inner struct {
+			Value string
+		}
+		object struct {
+			Base
+			Name   string `field:"name"`
+			secret string
+			nested inner
+		}
+	)
+	ctx := context.TODO()
+
+	t.Run("unexported", func(t *testing.T) {
+		obj := object{Base: Base{ID: 1}, Name: "name", secret: "s", nested: inner{Value: "v"}}
+		values := map[string]any{}
+		err := StructWalk(ctx, &obj, func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+			key := strings.Join(append(path, field.Name()), ".")
+			values[key] = field.Value()
+			if !field.StructField().IsExported() || len(path) > 0 && path[0] == "nested" {
+				assert.ErrorIs(t, field.SetValue(ctx, "x"), ErrStructFieldValueCantBeChanged, key)
+			}
+			return nil
+		}, WalkWithUnexported())
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"Base": Base{ID: 1}, "Base.ID": 1, "Name": "name",
+			"secret": "s", "nested": inner{Value: "v"}, "nested.Value": "v",
+		}, values)
+		assert.Equal(t, "s", obj.secret)
+		assert.Equal(t, "v", obj.nested.Value)
+	})
+
+	t.Run("flatten_embedded", func(t *testing.T) {
+		var paths []string
+		err := StructWalk(ctx, &object{}, func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+			paths = append(paths, strings.Join(append(path, field.Name()), "."))
+			return nil
+		}, WalkWithFlattenEmbedded())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Base", "ID", "Name"}, paths)
+	})
+
+	t.Run("field_info", func(t *testing.T) {
+		err := StructWalk(ctx, &object{}, func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+			switch field.Name() {
+			case "Base":
+				assert.True(t, field.IsEmbedded())
+				assert.Equal(t, 0, field.Index())
+				assert.Equal(t, reflect.TypeOf(Base{}), field.Type())
+			case "Name":
+				assert.False(t, field.IsEmbedded())
+				assert.Equal(t, 1, field.Index())
+				assert.Equal(t, reflect.TypeOf(""), field.Type())
+				assert.Equal(t, "name", field.StructField().Tag.Get("field"))
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+}
+
+type testWalkNode struct {
+	Name     string
+	Weight   int
+	Parent   *testWalkNode
+	Children []*testWalkNode
+}
+
+func TestStructWalkOnLeaveAndCycles(t *testing.T) {
+	ctx := context.TODO()
+	newTree := func() *testWalkNode {
+		root := &testWalkNode{Name: "root", Weight: 1}
+		root.Children = []*testWalkNode{
+			{Name: "a", Weight: 2, Parent: root},
+			{Name: "b", Weight: 3, Parent: root},
+		}
+		return root
+	}
+
+	t.Run("on_leave", func(t *testing.T) {
+		var order []string
+		err := StructWalk(ctx, newTree(), func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+			order = append(order, "enter:"+strings.Join(append(path, field.Name()), "."))
+			return nil
+		}, WalkWithSlices(), WalkWithMaxDepth(2), WalkWithOnLeave(
+			func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+				if field.Name() == "Children" {
+					order = append(order, "leave:"+field.Name())
+				}
+				return nil
+			}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"enter:Name", "enter:Weight", "enter:Parent",
+			"enter:Children", "enter:Children.0", "enter:Children.1", "leave:Children",
+		}, order)
+	})
+
+	t.Run("sum_bottom_up", func(t *testing.T) {
+		total := 0
+		err := StructWalk(ctx, newTree(), func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+			return nil
+		}, WalkWithSlices(), WalkWithOnLeave(
+			func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+				if field.Name() == "Weight" {
+					total += field.Value().(int)
+				}
+				return nil
+			}))
+		assert.NoError(t, err)
+		assert.Equal(t, 6, total)
+	})
+
+	t.Run("cycle_error", func(t *testing.T) {
+		err := StructWalk(ctx, newTree(), func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+			return nil
+		}, WalkWithSlices(), WalkWithCycleError())
+		assert.ErrorIs(t, err, ErrWalkCycle)
+		assert.Contains(t, err.Error(), "Children.0.Parent")
+	})
+
+	t.Run("shared_pointer", func(t *testing.T) {
+		shared := &testWalkNode{Name: "shared"}
+		root := &testWalkNode{Children: []*testWalkNode{shared, shared}}
+		count := 0
+		err := StructWalk(ctx, root, func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+			if field.Name() == "Name" && field.Value() == "shared" {
+				count++
+			}
+			return nil
+		}, WalkWithSlices(), WalkWithCycleError())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("max_depth", func(t *testing.T) {
+		maxLen := 0
+		err := StructWalk(ctx, newTree(), func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+			if len(path) > maxLen {
+				maxLen = len(path)
+			}
+			return nil
+		}, WalkWithSlices(), WalkWithMaxDepth(3))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, maxLen)
+	})
+}
@@ -0,0 +1,313 @@
+package gocast
+
+import (
+	"context"
+	"reflect"
+)
+
+// TransformOption defines the option of the Transform conversion
+type TransformOption func(opts *transformOptions)
+
+type transformOptions struct {
+	tags []string
+	copy CopyOptions
+}
+
+// WithTransformTags sets the struct tags used to match fields and map keys
+func WithTransformTags(tags ...string) TransformOption {
+	return func(opts *transformOptions) {
+		opts.tags = tags
+	}
+}
+
+// WithTransformCopyOptions sets the options of the deep copy of values with the same type
+func WithTransformCopyOptions(copyOpts CopyOptions) TransformOption {
+	return func(opts *transformOptions) {
+		opts.copy = copyOpts
+	}
+}
+
+// transformKey identifies the converted pointer, the same pointer can be converted into several types
+type transformKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type transformer struct {
+	ctx       context.Context
+	opts      transformOptions
+	visited   map[uintptr]reflect.Value // Values of the same type copied by deepCopyWithOptions
+	converted map[transformKey]reflect.Value
+}
+
+// TryTransform converts the source value into the new value of Dst type.
+// Unlike TryCast the result never shares memory with the source: values of the same type
+// are deep copied, other values are converted by the cast pipeline field by field.
+// Circular references are preserved in the result.
+//
+//	user, err := gocast.TryTransform[domain.User](userDTO, gocast.WithTransformTags("json"))
+func TryTransform[Dst any](src any, opts ...TransformOption) (Dst, error) {
+	return TryTransformContext[Dst](context.Background(), src, opts...)
+}
+
+// TryTransformContext converts the source value into the new value of Dst type with context
+func TryTransformContext[Dst any](ctx context.Context, src any, opts ...TransformOption) (Dst, error) {
+	var (
+		dst    Dst
+		trOpts transformOptions
+	)
+	for _, opt := range opts {
+		opt(&trOpts)
+	}
+	err := newTransformer(ctx, trOpts).transform(reflect.ValueOf(src), reflect.ValueOf(&dst).Elem())
+	return dst, err
+}
+
+// Transform converts the source value into the new value of Dst type or returns zero value
+func Transform[Dst any](src any, opts ...TransformOption) Dst {
+	dst, err := TryTransform[Dst](src, opts...)
+	if err != nil {
+		var zero Dst
+		return zero
+	}
+	return dst
+}
+
+func newTransformer(ctx context.Context, opts transformOptions) *transformer {
+	return &transformer{
+		ctx:       ctx,
+		opts:      opts,
+		visited:   make(map[uintptr]reflect.Value),
+		converted: make(map[transformKey]reflect.Value),
+	}
+}
+
+// transformTo returns the new value of the type t converted from the source by Transform rules
+func transformTo(ctx context.Context, src any, t reflect.Type, tags ...string) (reflect.Value, error) {
+	val := reflect.New(t).Elem()
+	err := newTransformer(ctx, transformOptions{tags: tags}).transform(reflect.ValueOf(src), val)
+	return val, err
+}
+
+func (tr *transformer) transform(src, dst reflect.Value) error {
+	for src.IsValid() && src.Kind() == reflect.Interface {
+		if src.IsNil() {
+			src = reflect.Value{}
+			break
+		}
+		src = src.Elem()
+	}
+	if !src.IsValid() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	if src.Type() == dst.Type() {
+		return deepCopyWithOptions(src, dst, tr.visited, tr.opts.copy, 0)
+	}
+
+	// Let the source define its own representation of the target type
+	if res, ok, err := castGetReflect(tr.ctx, src, dst.Type()); err != nil {
+		return err
+	} else if ok {
+		return tr.transform(reflect.ValueOf(res), dst)
+	}
+
+	switch dst.Kind() {
+	case reflect.Interface:
+		if src.Type().Implements(dst.Type()) {
+			return tr.copyValue(src, dst)
+		}
+		return tr.convert(src, dst)
+	case reflect.Pointer:
+		return tr.transformPointer(src, dst)
+	}
+
+	if setter, _ := dst.Addr().Interface().(CastSetter); setter != nil {
+		val := reflect.New(src.Type()).Elem()
+		if err := tr.copyValue(src, val); err != nil {
+			return err
+		}
+		return setter.CastSet(tr.ctx, val.Interface())
+	}
+
+	if src.Kind() == reflect.Pointer {
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		return tr.transform(src.Elem(), dst)
+	}
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		if (src.Kind() == reflect.Map || src.Kind() == reflect.Struct) &&
+			!isSQLNullType(src.Type()) && isMergeableStruct(dst.Type()) {
+			return tr.transformStruct(src, dst)
+		}
+	case reflect.Slice, reflect.Array:
+		if src.Kind() == reflect.Slice || src.Kind() == reflect.Array {
+			return tr.transformList(src, dst)
+		}
+	case reflect.Map:
+		if src.Kind() == reflect.Map {
+			return tr.transformMap(src, dst)
+		}
+	}
+	return tr.convert(src, dst)
+}
+
+func (tr *transformer) transformPointer(src, dst reflect.Value) error {
+	if src.Kind() != reflect.Pointer {
+		newPtr := reflect.New(dst.Type().Elem())
+		if err := tr.transform(src, newPtr.Elem()); err != nil {
+			return err
+		}
+		dst.Set(newPtr)
+		return nil
+	}
+	if src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	key := transformKey{ptr: src.Pointer(), typ: dst.Type()}
+	if existing, ok := tr.converted[key]; ok {
+		dst.Set(existing)
+		return nil
+	}
+
+	// Register the pointer before recursion to preserve circular references
+	newPtr := reflect.New(dst.Type().Elem())
+	tr.converted[key] = newPtr
+	dst.Set(newPtr)
+	return tr.transform(src.Elem(), newPtr.Elem())
+}
+
+func (tr *transformer) transformStruct(src, dst reflect.Value) error {
+	for _, ft := range ReflectStructFields(dst.Type()) {
+		if !ft.IsExported() {
+			continue
+		}
+		field := dst.FieldByName(ft.Name)
+		if !field.CanSet() {
+			continue
+		}
+
+		names := fieldNames(ft, tr.opts.tags...)
+		if len(names) < 1 {
+			continue
+		}
+
+		var (
+			val   reflect.Value
+			found bool
+		)
+		if src.Kind() == reflect.Map {
+			var v any
+			v, found = reflectMapValueByStringKeys(src, names)
+			val = reflect.ValueOf(v)
+		} else {
+			for _, name := range names {
+				if sf, ok := src.Type().FieldByName(name); ok && sf.IsExported() {
+					val, found = src.FieldByName(name), true
+					break
+				}
+			}
+		}
+		if !found {
+			continue
+		}
+
+		if reflectTarget(val).IsValid() {
+			// Validate and convert the value by the list of names from the enum tag
+			if enumTag := ft.Tag.Get("enum"); enumTag != "" {
+				v, err := enumTagValue(enumTag, field.Type(), val.Interface())
+				if err != nil {
+					return wrapError(err, ft.Name)
+				}
+				val = reflect.ValueOf(v)
+			}
+		} else if opt, _ := field.Addr().Interface().(optionalSetter); opt != nil {
+			opt.SetNull() // Explicit null differs from the absent value
+			continue
+		}
+
+		if err := tr.transform(val, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tr *transformer) transformList(src, dst reflect.Value) error {
+	size := src.Len()
+	if dst.Kind() == reflect.Slice {
+		if src.Kind() == reflect.Slice && src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		dst.Set(reflect.MakeSlice(dst.Type(), size, size))
+	} else if dst.Len() < size {
+		size = dst.Len()
+	}
+	for i := 0; i < size; i++ {
+		if err := tr.transform(src.Index(i), dst.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tr *transformer) transformMap(src, dst reflect.Value) error {
+	if src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	var (
+		dstType = dst.Type()
+		newMap  = reflect.MakeMapWithSize(dstType, src.Len())
+		iter    = src.MapRange()
+	)
+	for iter.Next() {
+		key := reflect.New(dstType.Key()).Elem()
+		if err := tr.transform(iter.Key(), key); err != nil {
+			return err
+		}
+		val := reflect.New(dstType.Elem()).Elem()
+		if err := tr.transform(iter.Value(), val); err != nil {
+			return err
+		}
+		newMap.SetMapIndex(key, val)
+	}
+	dst.Set(newMap)
+	return nil
+}
+
+// convert puts the value converted by the cast pipeline, the result is copied
+// because the pipeline can return the storage of the source
+func (tr *transformer) convert(src, dst reflect.Value) error {
+	val, err := ReflectTryToTypeContext(tr.ctx, src, dst.Type(), true, tr.opts.tags...)
+	if err != nil {
+		return err
+	}
+	if val == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	rv := reflect.ValueOf(val)
+	if rv.Type() != dst.Type() && rv.Type().ConvertibleTo(dst.Type()) {
+		rv = rv.Convert(dst.Type())
+	}
+	return tr.copyValue(rv, dst)
+}
+
+// copyValue puts the deep copy of the source value into the destination
+func (tr *transformer) copyValue(src, dst reflect.Value) error {
+	val := reflect.New(src.Type()).Elem()
+	if err := deepCopyWithOptions(src, val, tr.visited, tr.opts.copy, 0); err != nil {
+		return err
+	}
+	dst.Set(val)
+	return nil
+}
@@ -0,0 +1,140 @@
+package gocast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTransformAddressDTO struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type testTransformUserDTO struct {
+	ID        string                              `json:"id"`
+	Name      string                              `json:"name"`
+	Status    string                              `json:"status"`
+	Tags      []string                            `json:"tags"`
+	Scores    map[string]string                   `json:"scores"`
+	Address   *testTransformAddressDTO            `json:"address"`
+	Addresses []testTransformAddressDTO           `json:"addresses"`
+	Meta      map[string]any                      `json:"meta"`
+	Created   time.Time                           `json:"created"`
+	Friends   []*testTransformUserDTO             `json:"friends"`
+	Extra     map[string]*testTransformAddressDTO `json:"extra"`
+}
+
+type testTransformAddress struct {
+	City string
+	Zip  int
+}
+
+type testTransformUser struct {
+	ID        int64                           `json:"id"`
+	Name      string                          `json:"name"`
+	Status    testEnumStatus                  `json:"status"`
+	Tags      []string                        `json:"tags"`
+	Scores    map[string]float64              `json:"scores"`
+	Address   testTransformAddress            `json:"address"`
+	Addresses []*testTransformAddress         `json:"addresses"`
+	Meta      map[string]any                  `json:"meta"`
+	Created   time.Time                       `json:"created"`
+	Friends   []*testTransformUser            `json:"friends"`
+	Extra     map[string]testTransformAddress `json:"extra"`
+	Nick      Optional[string]                `json:"nick"`
+}
+
+func TestTransform(t *testing.T) {
+	created := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	dto := &testTransformUserDTO{
+		ID:        "42",
+		Name:      "Alice",
+		Status:    "active",
+		Tags:      []string{"admin"},
+		Scores:    map[string]string{"math": "4.5"},
+		Address:   &testTransformAddressDTO{City: "Berlin", Zip: "10115"},
+		Addresses: []testTransformAddressDTO{{City: "Paris", Zip: "75001"}},
+		Meta:      map[string]any{"nested": map[string]any{"a": []int{1}}},
+		Created:   created,
+		Extra:     map[string]*testTransformAddressDTO{"home": {City: "Rome", Zip: "00100"}},
+	}
+	dto.Friends = []*testTransformUserDTO{dto}
+
+	t.Run("struct", func(t *testing.T) {
+		user, err := TryTransform[testTransformUser](dto, WithTransformTags("json"))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, int64(42), user.ID)
+		assert.Equal(t, "Alice", user.Name)
+		assert.Equal(t, testEnumStatusActive, user.Status)
+		assert.Equal(t, []string{"admin"}, user.Tags)
+		assert.Equal(t, map[string]float64{"math": 4.5}, user.Scores)
+		assert.Equal(t, testTransformAddress{City: "Berlin", Zip: 10115}, user.Address)
+		assert.Equal(t, []*testTransformAddress{{City: "Paris", Zip: 75001}}, user.Addresses)
+		assert.Equal(t, created, user.Created)
+		assert.Equal(t, map[string]testTransformAddress{"home": {City: "Rome", Zip: 100}}, user.Extra)
+		assert.False(t, user.Nick.IsSet())
+	})
+
+	t.Run("no_aliasing", func(t *testing.T) {
+		user, err := TryTransform[testTransformUser](dto, WithTransformTags("json"))
+		if !assert.NoError(t, err) {
+			return
+		}
+		user.Tags[0] = "changed"
+		user.Meta["nested"].(map[string]any)["a"].([]int)[0] = 100
+		assert.Equal(t, "admin", dto.Tags[0])
+		assert.Equal(t, 1, dto.Meta["nested"].(map[string]any)["a"].([]int)[0])
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		user, err := TryTransform[*testTransformUser](dto, WithTransformTags("json"))
+		if !assert.NoError(t, err) || !assert.Len(t, user.Friends, 1) {
+			return
+		}
+		assert.Same(t, user, user.Friends[0])
+	})
+
+	t.Run("reverse", func(t *testing.T) {
+		user := testTransformUser{ID: 7, Status: testEnumStatusClosed, Address: testTransformAddress{City: "Oslo", Zip: 150}}
+		res, err := TryTransform[testTransformUserDTO](user, WithTransformTags("json"))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "7", res.ID)
+		assert.Equal(t, "closed", res.Status)
+		assert.Equal(t, &testTransformAddressDTO{City: "Oslo", Zip: "150"}, res.Address)
+		assert.Nil(t, res.Tags)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		src := map[string]any{"id": "3", "name": "Bob", "nick": nil, "tags": []any{"a", 1}}
+		user, err := TryTransform[testTransformUser](src, WithTransformTags("json"))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, int64(3), user.ID)
+		assert.Equal(t, []string{"a", "1"}, user.Tags)
+		assert.True(t, user.Nick.IsNull())
+
+		m := Transform[map[string]int](map[string]string{"a": "1"})
+		assert.Equal(t, map[string]int{"a": 1}, m)
+	})
+
+	t.Run("same_type", func(t *testing.T) {
+		src := []map[string][]int{{"a": {1, 2}}}
+		dst := Transform[[]map[string][]int](src)
+		assert.Equal(t, src, dst)
+		dst[0]["a"][0] = 10
+		assert.Equal(t, 1, src[0]["a"][0])
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := TryTransform[testTransformUser](map[string]any{"status": "unknown"}, WithTransformTags("json"))
+		assert.ErrorIs(t, err, ErrInvalidEnumValue)
+		assert.Equal(t, testTransformUser{}, Transform[testTransformUser](map[string]any{"name": "Bob", "tags": []any{"a"}, "ID": "abc"}))
+	})
+}
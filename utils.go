@@ -93,6 +93,16 @@ func IsEmptyByReflection(v reflect.Value) bool {
 		return v.Uint() == 0
 	case reflect.Float32, reflect.Float64:
 		return v.Float() == 0
+	case reflect.Struct:
+		// Like Optional, sql.Null* values are empty only if they are not Valid
+		if isSQLNullType(v.Type()) {
+			return !v.Field(1).Bool()
+		}
+		if v.CanInterface() {
+			if opt, ok := v.Interface().(optionalValue); ok {
+				return !opt.IsSet()
+			}
+		}
 	}
 	return false
 }
@@ -101,6 +111,9 @@ func getValue(v any) any {
 	if v == nil {
 		return nil
 	}
+	if nv, ok := unwrapSQLNull(v); ok {
+		return nv
+	}
 	if vl, ok := v.(driver.Valuer); ok {
 		v, _ = vl.Value()
 	}
@@ -134,3 +147,26 @@ func IsNil(v any) bool {
 	}
 	return false
 }
+
+// stringsEqual returns true if the lists contain the same strings in the same order
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// stringsContain returns true if the list contains the string
+func stringsContain(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,245 @@
+package gocast
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ValidationRuleFunc checks the field value by the rule parameter and returns the error
+// describing the violation, errors wrapping ErrInvalidParams report the invalid rule.
+// The value of pointer fields is dereferenced, nil values are checked only by "required".
+type ValidationRuleFunc func(ctx context.Context, value any, param string) error
+
+// ValidationError describes the violation of the rule by the field
+type ValidationError struct {
+	Path  string
+	Rule  string
+	Param string
+	Err   error
+}
+
+func (e *ValidationError) Error() string { return e.Path + ": " + e.Err.Error() }
+
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// Is makes every violation match ErrValidationFailed
+func (e *ValidationError) Is(target error) bool { return target == ErrValidationFailed }
+
+// ValidationErrors is the list of all violations returned by Validate
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is returns true if one of the violations matches the target,
+// the method is used instead of `Unwrap() []error` to support Go before 1.20
+func (e ValidationErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first violation which matches the target like errors.As
+func (e ValidationErrors) As(target any) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	validationRules sync.Map // map[string]ValidationRuleFunc
+	validationRegex sync.Map // map[string]*regexp.Regexp
+)
+
+func init() {
+	RegisterValidationRule("min", validateMin)
+	RegisterValidationRule("max", validateMax)
+	RegisterValidationRule("oneof", validateOneOf)
+	RegisterValidationRule("regex", validateRegex)
+}
+
+// RegisterValidationRule registers the custom rule of the validate tag,
+// the rule with the same name is replaced
+//
+//	gocast.RegisterValidationRule("even", func(ctx context.Context, value any, param string) error {
+//	    if gocast.Number[int](value)%2 != 0 {
+//	        return errors.New("must be even")
+//	    }
+//	    return nil
+//	})
+func RegisterValidationRule(name string, fn ValidationRuleFunc) {
+	validationRules.Store(name, fn)
+}
+
+// Validate checks the struct fields by the rules from the `validate` tag and returns
+// ValidationErrors with all violations. Nested structs, slices and maps are checked recursively,
+// the paths of violations are named by the first of the tags ("json") or field names.
+//
+// Built-in rules:
+//   - required — the value is not empty
+//   - min=N, max=N — the bounds of numbers or the length of strings, slices and maps
+//   - oneof=a b c — the value is one of the space separated list
+//   - regex=EXPR — the string matches the expression, must be the last rule of the tag
+//
+// Example:
+//
+//	type Config struct {
+//	    Name  string `json:"name" validate:"required,max=64"`
+//	    Port  int    `json:"port" validate:"min=1,max=65535"`
+//	    Mode  string `json:"mode" validate:"oneof=dev prod"`
+//	}
+//	err := gocast.Validate(ctx, &cfg, "json")
+func Validate(ctx context.Context, v any, tags ...string) error {
+	var errs ValidationErrors
+	err := StructWalk(ctx, v, func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) error {
+		tag := field.Tag("validate")
+		if tag == "" || tag == "-" {
+			return nil
+		}
+		fieldPath := strings.Join(appendPath(path, validationFieldName(field, tags)), ".")
+		value := derefValue(field.RefValue())
+		for _, rule := range parseValidationTag(tag) {
+			violation, err := validateRule(ctx, value, rule[0], rule[1])
+			if err != nil {
+				return wrapError(err, fieldPath)
+			}
+			if violation != nil {
+				errs = append(errs, &ValidationError{Path: fieldPath, Rule: rule[0], Param: rule[1], Err: violation})
+			}
+		}
+		return nil
+	}, WalkWithSlices(), WalkWithMaps(), WalkWithPathExtractor(
+		func(ctx context.Context, curObj StructWalkObject, field StructWalkField, path []string) string {
+			return validationFieldName(field, tags)
+		}))
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateRule returns the violation or the error of the invalid rule
+func validateRule(ctx context.Context, value reflect.Value, name, param string) (violation, err error) {
+	if name == "required" {
+		if !value.IsValid() || IsEmptyByReflection(value) || value.IsZero() {
+			return errors.New("value is required"), nil
+		}
+		return nil, nil
+	}
+	fn, ok := validationRules.Load(name)
+	if !ok {
+		return nil, wrapError(ErrUnknownValidationRule, name)
+	}
+	if !value.IsValid() || !value.CanInterface() {
+		return nil, nil
+	}
+	if err := fn.(ValidationRuleFunc)(ctx, value.Interface(), param); err != nil {
+		if errors.Is(err, ErrInvalidParams) {
+			return nil, wrapError(err, name)
+		}
+		return err, nil
+	}
+	return nil, nil
+}
+
+// parseValidationTag returns the list of [name, param] pairs,
+// the regex rule takes the rest of the tag as its parameter
+func parseValidationTag(tag string) [][2]string {
+	var rules [][2]string
+	for tag != "" {
+		var item string
+		item, tag, _ = strings.Cut(tag, ",")
+		name, param, _ := strings.Cut(strings.TrimSpace(item), "=")
+		if name == "regex" && tag != "" {
+			param, tag = param+","+tag, ""
+		}
+		if name != "" {
+			rules = append(rules, [2]string{name, param})
+		}
+	}
+	return rules
+}
+
+func validationFieldName(field StructWalkField, tags []string) string {
+	for _, tag := range tags {
+		if name, _, _ := strings.Cut(field.Tag(tag), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name()
+}
+
+// validationSize returns the number for numeric values and the length for strings and collections
+func validationSize(value any) (float64, error) {
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), nil
+	}
+	return TryNumber[float64](value)
+}
+
+func validateBound(value any, param string, check func(size, bound float64) bool, msg string) error {
+	bound, err := TryNumber[float64](param)
+	if err != nil {
+		return wrapError(ErrInvalidParams, "bound "+param)
+	}
+	size, err := validationSize(value)
+	if err != nil {
+		return errors.New("value is not a number")
+	}
+	if !check(size, bound) {
+		return errors.New(msg + " " + param)
+	}
+	return nil
+}
+
+func validateMin(ctx context.Context, value any, param string) error {
+	return validateBound(value, param, func(size, bound float64) bool { return size >= bound }, "must be at least")
+}
+
+func validateMax(ctx context.Context, value any, param string) error {
+	return validateBound(value, param, func(size, bound float64) bool { return size <= bound }, "must be at most")
+}
+
+func validateOneOf(ctx context.Context, value any, param string) error {
+	str := Str(value)
+	for _, item := range strings.Fields(param) {
+		if str == item {
+			return nil
+		}
+	}
+	return errors.New("must be one of " + param)
+}
+
+func validateRegex(ctx context.Context, value any, param string) error {
+	re, ok := validationRegex.Load(param)
+	if !ok {
+		compiled, err := regexp.Compile(param)
+		if err != nil {
+			return wrapError(ErrInvalidParams, "regex "+param)
+		}
+		re, _ = validationRegex.LoadOrStore(param, compiled)
+	}
+	if !re.(*regexp.Regexp).MatchString(Str(value)) {
+		return errors.New("must match " + param)
+	}
+	return nil
+}
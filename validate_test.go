@@ -0,0 +1,149 @@
+package gocast
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testValidateAddress struct {
+	City string `json:"city" validate:"required"`
+	Zip  string `json:"zip" validate:"regex=^[0-9]{5}$"`
+}
+
+type testValidateConfig struct {
+	Name      string                         `json:"name" validate:"required,max=8"`
+	Port      int                            `json:"port" validate:"min=1,max=65535"`
+	Mode      string                         `json:"mode" validate:"oneof=dev prod"`
+	Ratio     *float64                       `json:"ratio" validate:"min=0.5"`
+	Tags      []string                       `json:"tags" validate:"min=1"`
+	Address   *testValidateAddress           `json:"address" validate:"required"`
+	Mirrors   []testValidateAddress          `json:"mirrors"`
+	Regions   map[string]testValidateAddress `json:"regions"`
+	Even      int                            `json:"even" validate:"even"`
+	Timeout   string                         `json:"timeout" validate:"min=2"`
+	Unchecked string                         `json:"unchecked"`
+}
+
+func TestValidate(t *testing.T) {
+	ctx := context.TODO()
+	RegisterValidationRule("even", func(ctx context.Context, value any, param string) error {
+		if Number[int](value)%2 != 0 {
+			return errors.New("must be even")
+		}
+		return nil
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		ratio := 0.7
+		cfg := testValidateConfig{
+			Name:    "api",
+			Port:    8080,
+			Mode:    "prod",
+			Ratio:   &ratio,
+			Tags:    []string{"a"},
+			Address: &testValidateAddress{City: "Berlin", Zip: "10115"},
+			Mirrors: []testValidateAddress{{City: "Paris", Zip: "75001"}},
+			Even:    2,
+			Timeout: "10",
+		}
+		assert.NoError(t, Validate(ctx, &cfg, "json"))
+	})
+
+	t.Run("violations", func(t *testing.T) {
+		ratio := 0.1
+		cfg := testValidateConfig{
+			Name:    "too-long-name",
+			Port:    0,
+			Mode:    "test",
+			Ratio:   &ratio,
+			Mirrors: []testValidateAddress{{City: "Paris", Zip: "75001"}, {Zip: "1"}},
+			Regions: map[string]testValidateAddress{"eu": {City: "Rome", Zip: "abc"}},
+			Even:    3,
+			Timeout: "1",
+		}
+		err := Validate(ctx, &cfg, "json")
+		assert.ErrorIs(t, err, ErrValidationFailed)
+
+		var errs ValidationErrors
+		if !assert.True(t, errors.As(err, &errs)) {
+			return
+		}
+		var first *ValidationError
+		if assert.True(t, errors.As(err, &first)) {
+			assert.Same(t, errs[0], first)
+		}
+		violations := map[string]string{}
+		for _, e := range errs {
+			violations[e.Path] = e.Rule
+		}
+		assert.Equal(t, map[string]string{
+			"name":           "max",
+			"port":           "min",
+			"mode":           "oneof",
+			"ratio":          "min",
+			"tags":           "min",
+			"address":        "required",
+			"mirrors.1.city": "required",
+			"mirrors.1.zip":  "regex",
+			"regions.eu.zip": "regex",
+			"even":           "even",
+			"timeout":        "min",
+		}, violations)
+		assert.Contains(t, err.Error(), "name: must be at most 8")
+	})
+
+	t.Run("field_names", func(t *testing.T) {
+		err := Validate(ctx, &testValidateConfig{Address: &testValidateAddress{}})
+		var errs ValidationErrors
+		if assert.True(t, errors.As(err, &errs)) {
+			var paths []string
+			for _, e := range errs {
+				paths = append(paths, e.Path)
+			}
+			assert.Contains(t, paths, "Name")
+			assert.Contains(t, paths, "Address.City")
+		}
+	})
+
+	t.Run("invalid_rule", func(t *testing.T) {
+		type invalid struct {
+			Value int `validate:"unknown"`
+			Min   int `validate:"min=abc"`
+		}
+		assert.ErrorIs(t, Validate(ctx, &invalid{}), ErrUnknownValidationRule)
+
+		type invalidBound struct {
+			Min int `validate:"min=abc"`
+		}
+		assert.ErrorIs(t, Validate(ctx, &invalidBound{}), ErrInvalidParams)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		// Validation only reads the value, so the same value can be validated in parallel
+		cfg := testValidateConfig{
+			Name:    "api",
+			Port:    8080,
+			Mode:    "dev",
+			Tags:    []string{"a"},
+			Address: &testValidateAddress{City: "Berlin", Zip: "10115"},
+			Regions: map[string]testValidateAddress{"eu": {City: "Rome", Zip: "00100"}, "us": {City: "Austin", Zip: "73301"}},
+			Timeout: "10",
+		}
+		for i := 0; i < 4; i++ {
+			t.Run(strconv.Itoa(i), func(t *testing.T) {
+				t.Parallel()
+				for j := 0; j < 100; j++ {
+					assert.NoError(t, Validate(ctx, &cfg, "json"))
+				}
+			})
+		}
+	})
+
+	t.Run("parse_tag", func(t *testing.T) {
+		assert.Equal(t, [][2]string{{"required", ""}, {"regex", "^a{1,2}$"}}, parseValidationTag("required,regex=^a{1,2}$"))
+	})
+}